@@ -0,0 +1,81 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendMatchesMarshalBinary(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 2.5, Quantity: 3, TradeID: 4, Side: SideBuy}
+	order := OrderBody{TickerID: 5, OrderID: 6, Price: 7.5, Quantity: 8, TypeAndSide: OrderTypeStop<<1 | SideSell}
+	ticker := TickerBody{TickerID: 9, BidPrice: 10.5, AskPrice: 10.6, BidVolume: 11, AskVolume: 12}
+
+	want, _ := trade.MarshalBinary()
+	if got := AppendTrade(nil, trade); !bytes.Equal(got, want) {
+		t.Errorf("AppendTrade = %x, want %x", got, want)
+	}
+	want, _ = order.MarshalBinary()
+	if got := AppendOrder(nil, order); !bytes.Equal(got, want) {
+		t.Errorf("AppendOrder = %x, want %x", got, want)
+	}
+	want, _ = ticker.MarshalBinary()
+	if got := AppendTicker(nil, ticker); !bytes.Equal(got, want) {
+		t.Errorf("AppendTicker = %x, want %x", got, want)
+	}
+}
+
+func TestAppendExtendsExisting(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 2, Quantity: 3, TradeID: 4}
+	buf := AppendHeader(nil, MitchHeader{MessageType: MsgTypeTrade, Count: 2})
+	buf = AppendTrade(buf, trade)
+	buf = AppendTrade(buf, trade)
+	if len(buf) != HeaderSize+2*BodySizeFixed {
+		t.Errorf("buffer length = %d, want %d", len(buf), HeaderSize+2*BodySizeFixed)
+	}
+}
+
+func BenchmarkAppendTrade(b *testing.B) {
+	trade := TradeBody{TickerID: 1, Price: 1234.5, Quantity: 100, TradeID: 7, Side: SideBuy}
+	buf := make([]byte, 0, BodySizeFixed)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = AppendTrade(buf[:0], trade)
+	}
+}
+
+func BenchmarkPackMessageReflect(b *testing.B) {
+	trade := TradeBody{TickerID: 1, Price: 1234.5, Quantity: 100, TradeID: 7, Side: SideBuy}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := PackMessage(MsgTypeTrade, trade); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAppendOrderBookMatchesPack(t *testing.T) {
+	book := OrderBookBody{TickerID: 9, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: SideSell}
+	volumes := []uint32{7, 0, 11}
+
+	packed, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{volumes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appended := AppendOrderBook(nil, book, volumes)
+	if !bytes.Equal(appended, packed[HeaderSize:]) {
+		t.Errorf("AppendOrderBook = %x, want %x", appended, packed[HeaderSize:])
+	}
+}
+
+func BenchmarkAppendOrderBook(b *testing.B) {
+	book := OrderBookBody{TickerID: 9, FirstTick: 100, TickSize: 0.5, NumTicks: 32, Side: SideSell}
+	volumes := make([]uint32, 32)
+	buf := make([]byte, 0, BodySizeFixed+len(volumes)*4)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = AppendOrderBook(buf[:0], book, volumes)
+	}
+}
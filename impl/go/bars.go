@@ -0,0 +1,115 @@
+// Trade aggregation into OHLCV bars.
+//
+// Charting wants candles, not raw prints. BarAggregator collects trades
+// and bins them on demand: because binning happens in Bars rather than on
+// arrival, trades landing slightly out of order within a bar still
+// aggregate correctly — open and close are decided by trade time, not
+// arrival order.
+
+package mitch
+
+import (
+	"sort"
+	"time"
+)
+
+// Bar is one OHLCV candle for one instrument.
+type Bar struct {
+	TickerID uint64
+	Start    time.Time // bar open time, aligned to the interval
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   uint64
+	VWAP     float64
+}
+
+// barTrade is one collected print.
+type barTrade struct {
+	tickerID uint64
+	price    float64
+	quantity uint32
+	time     time.Time
+}
+
+// BarAggregator rolls trades into OHLCV bars. It keeps every added trade
+// until Reset, so long-running consumers should aggregate in windows. Not
+// safe for concurrent use.
+type BarAggregator struct {
+	trades []barTrade
+}
+
+// NewBarAggregator returns an empty aggregator.
+func NewBarAggregator() *BarAggregator {
+	return &BarAggregator{}
+}
+
+// AddTrade records one trade at its absolute time (the 48-bit header
+// timestamp combined with the trading day; see TimestampToTime).
+func (a *BarAggregator) AddTrade(trade *TradeBody, tradeTime time.Time) {
+	a.trades = append(a.trades, barTrade{
+		tickerID: trade.TickerID,
+		price:    trade.Price,
+		quantity: trade.Quantity,
+		time:     tradeTime,
+	})
+}
+
+// Reset discards all collected trades.
+func (a *BarAggregator) Reset() {
+	a.trades = a.trades[:0]
+}
+
+// Bars bins the collected trades into candles of the given interval, one
+// series per TickerID, ordered by ticker then bar start. The last bar of
+// each series covers a possibly still-open interval. VWAP weights by
+// quantity; a bar whose trades all have zero quantity reports its close
+// as VWAP.
+func (a *BarAggregator) Bars(interval time.Duration) []Bar {
+	if interval <= 0 || len(a.trades) == 0 {
+		return nil
+	}
+	sorted := make([]barTrade, len(a.trades))
+	copy(sorted, a.trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].tickerID != sorted[j].tickerID {
+			return sorted[i].tickerID < sorted[j].tickerID
+		}
+		return sorted[i].time.Before(sorted[j].time)
+	})
+
+	var bars []Bar
+	var notional float64
+	for _, trade := range sorted {
+		start := trade.time.Truncate(interval)
+		last := len(bars) - 1
+		if last < 0 || bars[last].TickerID != trade.tickerID || !bars[last].Start.Equal(start) {
+			bars = append(bars, Bar{
+				TickerID: trade.tickerID,
+				Start:    start,
+				Open:     trade.price,
+				High:     trade.price,
+				Low:      trade.price,
+			})
+			last++
+			notional = 0
+		}
+		bar := &bars[last]
+		if trade.price > bar.High {
+			bar.High = trade.price
+		}
+		if trade.price < bar.Low {
+			bar.Low = trade.price
+		}
+		bar.Close = trade.price
+		bar.Volume += uint64(trade.quantity)
+		notional += trade.price * float64(trade.quantity)
+		if bar.Volume > 0 {
+			bar.VWAP = notional / float64(bar.Volume)
+		} else {
+			bar.VWAP = bar.Close
+		}
+	}
+	return bars
+}
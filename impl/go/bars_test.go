@@ -0,0 +1,69 @@
+package mitch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBarAggregatorOHLCV(t *testing.T) {
+	base := time.Date(2025, time.June, 2, 10, 0, 0, 0, time.UTC)
+	a := NewBarAggregator()
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 100, Quantity: 10}, base.Add(1*time.Second))
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 105, Quantity: 20}, base.Add(20*time.Second))
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 95, Quantity: 10}, base.Add(40*time.Second))
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 102, Quantity: 10}, base.Add(59*time.Second))
+	// Next interval.
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 103, Quantity: 5}, base.Add(61*time.Second))
+
+	bars := a.Bars(time.Minute)
+	if len(bars) != 2 {
+		t.Fatalf("got %d bars, want 2", len(bars))
+	}
+	first := bars[0]
+	if first.Open != 100 || first.High != 105 || first.Low != 95 || first.Close != 102 {
+		t.Errorf("OHLC = %v/%v/%v/%v, want 100/105/95/102", first.Open, first.High, first.Low, first.Close)
+	}
+	if first.Volume != 50 {
+		t.Errorf("Volume = %d, want 50", first.Volume)
+	}
+	wantVWAP := (100.0*10 + 105*20 + 95*10 + 102*10) / 50
+	if diff := first.VWAP - wantVWAP; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("VWAP = %v, want %v", first.VWAP, wantVWAP)
+	}
+	if second := bars[1]; second.Open != 103 || second.Volume != 5 {
+		t.Errorf("second bar = %+v", second)
+	}
+}
+
+func TestBarAggregatorOutOfOrderWithinBar(t *testing.T) {
+	base := time.Date(2025, time.June, 2, 10, 0, 0, 0, time.UTC)
+	a := NewBarAggregator()
+	// The later trade arrives first; open/close must follow trade time.
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 110, Quantity: 1}, base.Add(50*time.Second))
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 90, Quantity: 1}, base.Add(5*time.Second))
+
+	bars := a.Bars(time.Minute)
+	if len(bars) != 1 {
+		t.Fatalf("got %d bars, want 1", len(bars))
+	}
+	if bars[0].Open != 90 || bars[0].Close != 110 {
+		t.Errorf("open/close = %v/%v, want 90/110", bars[0].Open, bars[0].Close)
+	}
+}
+
+func TestBarAggregatorPerTicker(t *testing.T) {
+	base := time.Date(2025, time.June, 2, 10, 0, 0, 0, time.UTC)
+	a := NewBarAggregator()
+	a.AddTrade(&TradeBody{TickerID: 2, Price: 5, Quantity: 1}, base)
+	a.AddTrade(&TradeBody{TickerID: 1, Price: 7, Quantity: 1}, base)
+
+	bars := a.Bars(time.Minute)
+	if len(bars) != 2 || bars[0].TickerID != 1 || bars[1].TickerID != 2 {
+		t.Fatalf("bars = %+v, want one per ticker ordered by ID", bars)
+	}
+
+	a.Reset()
+	if got := a.Bars(time.Minute); got != nil {
+		t.Errorf("Bars after Reset = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,129 @@
+// Time-windowed batching publisher.
+//
+// Publishing one TCP write per trade wastes syscalls and packs one-body
+// frames. BatchPublisher accumulates bodies and flushes each type's batch
+// as a single message when it reaches the 255-body frame cap or when the
+// flush interval elapses, whichever comes first. Frames are single-type,
+// so bodies are grouped by message type and each type flushes as its own
+// message.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// BatchPublisher batches bodies into single packed messages per type.
+// Create it with NewBatchPublisher; Add and Close are safe for concurrent
+// use.
+type BatchPublisher struct {
+	w        io.Writer
+	interval time.Duration
+
+	mu      sync.Mutex
+	batches map[MessageType][]interface{}
+	err     error
+	closed  bool
+
+	done   chan struct{}
+	ticker *time.Ticker
+	wg     sync.WaitGroup
+}
+
+// NewBatchPublisher returns a publisher writing batched messages to w,
+// flushing every interval even when batches are small.
+func NewBatchPublisher(w io.Writer, interval time.Duration) *BatchPublisher {
+	p := &BatchPublisher{
+		w:        w,
+		interval: interval,
+		batches:  make(map[MessageType][]interface{}),
+		done:     make(chan struct{}),
+		ticker:   time.NewTicker(interval),
+	}
+	p.wg.Add(1)
+	go p.loop()
+	return p
+}
+
+// Add queues one body for the given message type, flushing that type's
+// batch immediately when it reaches the 255-body frame cap. The first
+// flush error is sticky and returned from Add and Close.
+func (p *BatchPublisher) Add(messageType MessageType, body interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return fmt.Errorf("mitch: batch publisher is closed")
+	}
+	if p.err != nil {
+		return p.err
+	}
+	p.batches[messageType] = append(p.batches[messageType], body)
+	if len(p.batches[messageType]) >= 255 {
+		p.flushTypeLocked(messageType)
+	}
+	return p.err
+}
+
+// Close flushes all remaining bodies and stops the flush timer. It returns
+// the first error encountered by any flush.
+func (p *BatchPublisher) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return p.err
+	}
+	p.closed = true
+	p.flushAllLocked()
+	err := p.err
+	p.mu.Unlock()
+
+	close(p.done)
+	p.ticker.Stop()
+	p.wg.Wait()
+	return err
+}
+
+// loop flushes pending batches every interval until Close.
+func (p *BatchPublisher) loop() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-p.ticker.C:
+			p.mu.Lock()
+			if !p.closed {
+				p.flushAllLocked()
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// flushAllLocked flushes every non-empty batch. Callers hold p.mu.
+func (p *BatchPublisher) flushAllLocked() {
+	for messageType := range p.batches {
+		p.flushTypeLocked(messageType)
+	}
+}
+
+// flushTypeLocked packs and writes one type's pending batch. Callers hold
+// p.mu; the first error is recorded and later batches are dropped.
+func (p *BatchPublisher) flushTypeLocked(messageType MessageType) {
+	bodies := p.batches[messageType]
+	if len(bodies) == 0 || p.err != nil {
+		return
+	}
+	message, err := PackMessage(messageType, bodies...)
+	if err == nil {
+		_, err = p.w.Write(message)
+	}
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.batches[messageType] = nil
+}
@@ -0,0 +1,97 @@
+package mitch
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is an io.Writer safe for the publisher's flush goroutine.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) messages(t *testing.T) []*MitchHeader {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var headers []*MitchHeader
+	decoder := NewDecoder(bytes.NewReader(b.buf.Bytes()))
+	for {
+		header, _, err := decoder.Decode()
+		if err != nil {
+			return headers
+		}
+		headers = append(headers, header)
+	}
+}
+
+func TestBatchPublisherFlushOnCap(t *testing.T) {
+	var out lockedBuffer
+	p := NewBatchPublisher(&out, time.Hour)
+	defer p.Close()
+
+	for i := 0; i < 255; i++ {
+		if err := p.Add(MsgTypeTrade, TradeBody{TradeID: uint32(i)}); err != nil {
+			t.Fatalf("Add %d: %v", i, err)
+		}
+	}
+	headers := out.messages(t)
+	if len(headers) != 1 || headers[0].Count != 255 {
+		t.Fatalf("headers = %+v, want one frame of 255 bodies", headers)
+	}
+}
+
+func TestBatchPublisherFlushOnInterval(t *testing.T) {
+	var out lockedBuffer
+	p := NewBatchPublisher(&out, 20*time.Millisecond)
+	defer p.Close()
+
+	if err := p.Add(MsgTypeTicker, TickerBody{TickerID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(out.messages(t)) == 1 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("interval flush never happened")
+}
+
+func TestBatchPublisherGroupsByTypeAndCloses(t *testing.T) {
+	var out lockedBuffer
+	p := NewBatchPublisher(&out, time.Hour)
+
+	p.Add(MsgTypeTrade, TradeBody{TradeID: 1})
+	p.Add(MsgTypeTicker, TickerBody{TickerID: 2})
+	p.Add(MsgTypeTrade, TradeBody{TradeID: 3})
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	headers := out.messages(t)
+	if len(headers) != 2 {
+		t.Fatalf("got %d frames, want 2 (one per type)", len(headers))
+	}
+	counts := map[MessageType]uint8{}
+	for _, h := range headers {
+		counts[h.MessageType] = h.Count
+	}
+	if counts[MsgTypeTrade] != 2 || counts[MsgTypeTicker] != 1 {
+		t.Errorf("counts = %v, want trade:2 ticker:1", counts)
+	}
+
+	if err := p.Add(MsgTypeTrade, TradeBody{}); err == nil {
+		t.Error("Add after Close must error")
+	}
+}
@@ -0,0 +1,166 @@
+// Live order book maintenance from incremental updates.
+//
+// Order book messages carry one side's levels over a price grid; a consumer
+// tracking the live book has to merge each update into what it already
+// holds. Book keeps both sides keyed by price so updates whose tick range
+// only partially overlaps the current state merge correctly, and guards
+// state with an RWMutex for one-writer/many-reader access.
+
+package mitch
+
+import (
+	"sort"
+	"sync"
+)
+
+// Level is one price level of a maintained book.
+type Level struct {
+	Price  float64
+	Volume uint32
+}
+
+// Book maintains the live state of both sides of one instrument's order
+// book. Apply merges incremental updates; Snapshot returns sorted copies
+// for readers. Safe for one concurrent writer and many readers.
+//
+// A crossed book — best bid above best ask — means bad data, usually a
+// missed message or a venue glitch. Set OnCross and/or DropCrossing
+// before the first update to detect it; both are checked after every
+// Apply and ApplyDelta.
+type Book struct {
+	// OnCross, if non-nil, is called with the crossing best bid and ask
+	// whenever an update leaves the book crossed. It runs under the
+	// book's lock and must not call back into the book.
+	OnCross func(bestBid, bestAsk float64)
+
+	// DropCrossing reverts any update that would cross the book, keeping
+	// the previous state. It costs a copy of the updated side per Apply,
+	// so leave it off unless the feed is known to glitch.
+	DropCrossing bool
+
+	mu   sync.RWMutex
+	bids map[float64]uint32
+	asks map[float64]uint32
+}
+
+// NewBook returns an empty book.
+func NewBook() *Book {
+	return &Book{
+		bids: make(map[float64]uint32),
+		asks: make(map[float64]uint32),
+	}
+}
+
+// Apply merges one side's update into the book. Every level covered by the
+// update's tick range is set to the carried volume; a zero volume removes
+// the level. Prices outside the update's range are left untouched, so
+// partial-depth updates only affect the window they cover.
+func (b *Book) Apply(body *OrderBookBody, volumes []uint32) {
+	side := b.asks
+	if body.Side == SideBuy {
+		side = b.bids
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	saved := b.saveSideLocked(side)
+	body.Iterate(volumes, func(price float64, volume uint32) {
+		if volume == 0 {
+			delete(side, price)
+		} else {
+			side[price] = volume
+		}
+	})
+	b.checkCrossLocked(body.Side, saved)
+}
+
+// Snapshot returns copies of both sides, bids sorted best (highest) first
+// and asks best (lowest) first.
+func (b *Book) Snapshot() (bids, asks []Level) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bids = make([]Level, 0, len(b.bids))
+	for price, volume := range b.bids {
+		bids = append(bids, Level{price, volume})
+	}
+	asks = make([]Level, 0, len(b.asks))
+	for price, volume := range b.asks {
+		asks = append(asks, Level{price, volume})
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return bids, asks
+}
+
+// IsCrossed reports whether the best bid is above the best ask. An empty
+// side never crosses.
+func (b *Book) IsCrossed() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, _, crossed := b.crossedLocked()
+	return crossed
+}
+
+// crossedLocked returns the best bid and ask and whether they cross.
+// Callers must hold at least a read lock.
+func (b *Book) crossedLocked() (bestBid, bestAsk float64, crossed bool) {
+	if len(b.bids) == 0 || len(b.asks) == 0 {
+		return 0, 0, false
+	}
+	first := true
+	for price := range b.bids {
+		if first || price > bestBid {
+			bestBid = price
+		}
+		first = false
+	}
+	first = true
+	for price := range b.asks {
+		if first || price < bestAsk {
+			bestAsk = price
+		}
+		first = false
+	}
+	return bestBid, bestAsk, bestBid > bestAsk
+}
+
+// saveSideLocked copies a side before an update when DropCrossing needs a
+// state to roll back to; otherwise it returns nil.
+func (b *Book) saveSideLocked(side map[float64]uint32) map[float64]uint32 {
+	if !b.DropCrossing {
+		return nil
+	}
+	saved := make(map[float64]uint32, len(side))
+	for price, volume := range side {
+		saved[price] = volume
+	}
+	return saved
+}
+
+// checkCrossLocked runs the crossed-book policy after an update: report
+// the crossing prices through OnCross, and with DropCrossing restore the
+// updated side from its pre-update copy.
+func (b *Book) checkCrossLocked(side Side, saved map[float64]uint32) {
+	bestBid, bestAsk, crossed := b.crossedLocked()
+	if !crossed {
+		return
+	}
+	if b.OnCross != nil {
+		b.OnCross(bestBid, bestAsk)
+	}
+	if saved != nil {
+		if side == SideBuy {
+			b.bids = saved
+		} else {
+			b.asks = saved
+		}
+	}
+}
+
+// Depth returns the current number of levels on each side.
+func (b *Book) Depth() (bids, asks int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.bids), len(b.asks)
+}
@@ -0,0 +1,110 @@
+// Incremental construction of order book messages.
+//
+// Filling an OrderBookBody and its parallel volume slice by hand means
+// computing FirstTick, TickSize and level order manually and is easy to
+// get wrong. The builder accumulates (price, volume) levels in any order,
+// infers the grid and validates uniform spacing on Build.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// tickSpacingTolerance is the relative tolerance used when checking that
+// levels sit on a uniform price grid, absorbing float64 rounding in prices
+// that arrive from decimal sources.
+const tickSpacingTolerance = 1e-9
+
+// BookBuilder accumulates price levels and produces an order book body
+// with its volume slice. The zero value is ready to use.
+type BookBuilder struct {
+	tickerID uint64
+	side     Side
+	prices   []float64
+	volumes  []uint32
+}
+
+// NewBookBuilder returns a builder for one side of a ticker's book.
+func NewBookBuilder(tickerID uint64, side Side) *BookBuilder {
+	return &BookBuilder{tickerID: tickerID, side: side}
+}
+
+// AddLevel records one price level. Levels may be added in any order;
+// Build sorts them into the wire's best-first storage order.
+func (b *BookBuilder) AddLevel(price float64, volume uint32) {
+	b.prices = append(b.prices, price)
+	b.volumes = append(b.volumes, volume)
+}
+
+// Len returns the number of levels added so far.
+func (b *BookBuilder) Len() int {
+	return len(b.prices)
+}
+
+// Build validates the accumulated levels and produces the book header and
+// volume slice. It infers FirstTick (the best level) and TickSize from the
+// level prices and errors if the levels are not evenly spaced, since the
+// MITCH order book format assumes a fixed tick size. A single level builds
+// with TickSize zero.
+func (b *BookBuilder) Build() (OrderBookBody, []uint32, error) {
+	if len(b.prices) == 0 {
+		return OrderBookBody{}, nil, fmt.Errorf("mitch: book builder has no levels")
+	}
+	if len(b.prices) > math.MaxUint16 {
+		return OrderBookBody{}, nil, fmt.Errorf("mitch: book builder has %d levels (max %d)", len(b.prices), math.MaxUint16)
+	}
+
+	type level struct {
+		price  float64
+		volume uint32
+	}
+	levels := make([]level, len(b.prices))
+	for i := range b.prices {
+		if !validFloat(b.prices[i]) {
+			return OrderBookBody{}, nil, fmt.Errorf("mitch: level %d has invalid price %v", i, b.prices[i])
+		}
+		levels[i] = level{b.prices[i], b.volumes[i]}
+	}
+	// Storage order is best-first: ascending prices for asks, descending
+	// for bids.
+	sort.Slice(levels, func(i, j int) bool {
+		if b.side == SideBuy {
+			return levels[i].price > levels[j].price
+		}
+		return levels[i].price < levels[j].price
+	})
+
+	var tickSize float64
+	if len(levels) > 1 {
+		tickSize = math.Abs(levels[1].price - levels[0].price)
+		if tickSize == 0 {
+			return OrderBookBody{}, nil, fmt.Errorf("mitch: duplicate level price %v", levels[0].price)
+		}
+		for i := 1; i < len(levels); i++ {
+			gap := math.Abs(levels[i].price - levels[i-1].price)
+			if math.Abs(gap-tickSize) > tickSpacingTolerance*math.Max(gap, tickSize) {
+				return OrderBookBody{}, nil, fmt.Errorf("mitch: uneven tick spacing: gap %v between levels %d and %d, expected %v",
+					gap, i-1, i, tickSize)
+			}
+		}
+	}
+
+	volumes := make([]uint32, len(levels))
+	for i, l := range levels {
+		volumes[i] = l.volume
+	}
+	book := OrderBookBody{
+		TickerID:  b.tickerID,
+		FirstTick: levels[0].price,
+		TickSize:  tickSize,
+		NumTicks:  uint16(len(levels)),
+		Side:      b.side,
+	}
+	if err := book.Validate(); err != nil {
+		return OrderBookBody{}, nil, err
+	}
+	return book, volumes, nil
+}
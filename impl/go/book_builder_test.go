@@ -0,0 +1,103 @@
+package mitch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBookBuilderAsks(t *testing.T) {
+	b := NewBookBuilder(7, SideSell)
+	// Added out of order on purpose.
+	b.AddLevel(100.5, 20)
+	b.AddLevel(100.0, 10)
+	b.AddLevel(101.0, 30)
+
+	book, volumes, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if book.TickerID != 7 || book.Side != SideSell {
+		t.Errorf("book = %+v", book)
+	}
+	if book.FirstTick != 100.0 || book.TickSize != 0.5 || book.NumTicks != 3 {
+		t.Errorf("grid = (%g, %g, %d), want (100, 0.5, 3)", book.FirstTick, book.TickSize, book.NumTicks)
+	}
+	want := []uint32{10, 20, 30}
+	for i := range want {
+		if volumes[i] != want[i] {
+			t.Errorf("volume %d = %d, want %d", i, volumes[i], want[i])
+		}
+	}
+}
+
+func TestBookBuilderBidsDescend(t *testing.T) {
+	b := NewBookBuilder(1, SideBuy)
+	b.AddLevel(99.0, 1)
+	b.AddLevel(99.5, 2)
+	b.AddLevel(100.0, 3)
+
+	book, volumes, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if book.FirstTick != 100.0 {
+		t.Errorf("FirstTick = %g, want best bid 100", book.FirstTick)
+	}
+	if volumes[0] != 3 || volumes[2] != 1 {
+		t.Errorf("volumes = %v, want best-first [3 2 1]", volumes)
+	}
+	if price := book.LevelPrice(2); price != 99.0 {
+		t.Errorf("LevelPrice(2) = %g, want 99", price)
+	}
+}
+
+func TestBookBuilderErrors(t *testing.T) {
+	if _, _, err := NewBookBuilder(1, SideBuy).Build(); err == nil {
+		t.Error("empty builder must error")
+	}
+
+	uneven := NewBookBuilder(1, SideSell)
+	uneven.AddLevel(100.0, 1)
+	uneven.AddLevel(100.5, 2)
+	uneven.AddLevel(101.25, 3)
+	if _, _, err := uneven.Build(); err == nil || !strings.Contains(err.Error(), "uneven tick spacing") {
+		t.Errorf("uneven spacing: got %v", err)
+	}
+
+	dup := NewBookBuilder(1, SideSell)
+	dup.AddLevel(100.0, 1)
+	dup.AddLevel(100.0, 2)
+	if _, _, err := dup.Build(); err == nil {
+		t.Error("duplicate price must error")
+	}
+}
+
+func TestBookBuilderSingleLevel(t *testing.T) {
+	b := NewBookBuilder(1, SideSell)
+	b.AddLevel(42.0, 9)
+	book, volumes, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if book.FirstTick != 42.0 || book.TickSize != 0 || book.NumTicks != 1 || volumes[0] != 9 {
+		t.Errorf("book = %+v volumes = %v", book, volumes)
+	}
+}
+
+func TestBookBuilderPacksRoundTrip(t *testing.T) {
+	b := NewBookBuilder(3, SideSell)
+	for i := 0; i < 5; i++ {
+		b.AddLevel(10.0+float64(i)*0.1, uint32(i+1))
+	}
+	book, volumes, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	message, err := PackOrderBook(MitchHeader{}, book, volumes)
+	if err != nil {
+		t.Fatalf("PackOrderBook: %v", err)
+	}
+	if _, _, _, err := UnpackOrderBook(message); err != nil {
+		t.Fatalf("UnpackOrderBook: %v", err)
+	}
+}
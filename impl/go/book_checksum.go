@@ -0,0 +1,113 @@
+// Order book state checksums.
+//
+// A consumer applying incremental updates has no way to notice a silently
+// diverged book. The publisher periodically sends a checksum of its own
+// top levels; the consumer computes the same function over its maintained
+// book and re-requests a snapshot on mismatch. The algorithm is
+// Kraken-style: CRC32 (IEEE) over the top checksumDepth asks then bids,
+// each level contributing its big-endian price bits and volume.
+
+package mitch
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+	"time"
+)
+
+// MsgTypeBookChecksum is the message type code for book checksums.
+const MsgTypeBookChecksum MessageType = 'c'
+
+// BookChecksumBodySize is the book checksum body size in bytes.
+const BookChecksumBodySize = 16
+
+// checksumDepth is how many levels per side feed the checksum. Deep levels
+// churn constantly and diverge harmlessly; the top of book is what matters.
+const checksumDepth = 10
+
+// BookChecksumBody carries the publisher's expected checksum for one
+// instrument's book (16 bytes).
+type BookChecksumBody struct {
+	TickerID uint64
+	Checksum uint32
+	Padding  [4]byte // padding to 16 bytes
+}
+
+// MarshalBinary encodes the checksum body into its 16-byte wire layout.
+func (b BookChecksumBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, BookChecksumBodySize)
+	ByteOrder.PutUint64(data[0:8], b.TickerID)
+	ByteOrder.PutUint32(data[8:12], b.Checksum)
+	// data[12:16] stays zero: checksum padding carries no flags.
+	return data, nil
+}
+
+// UnmarshalBinary decodes the checksum body from its 16-byte wire layout.
+func (b *BookChecksumBody) UnmarshalBinary(data []byte) error {
+	if len(data) < BookChecksumBodySize {
+		return fmt.Errorf("mitch: %w: book checksum body is %d bytes (need %d)", ErrShortBuffer, len(data), BookChecksumBodySize)
+	}
+	b.TickerID = ByteOrder.Uint64(data[0:8])
+	b.Checksum = ByteOrder.Uint32(data[8:12])
+	copy(b.Padding[:], data[12:16])
+	return nil
+}
+
+// BookChecksum computes the deterministic checksum of a book from its
+// sorted sides, best level first, as returned by Book.Snapshot. Only the
+// top checksumDepth levels per side participate, asks before bids.
+func BookChecksum(bids, asks []Level) uint32 {
+	buf := make([]byte, 0, 2*checksumDepth*12)
+	buf = appendChecksumLevels(buf, asks)
+	buf = appendChecksumLevels(buf, bids)
+	return crc32.ChecksumIEEE(buf)
+}
+
+// appendChecksumLevels appends up to checksumDepth wire-encoded levels.
+func appendChecksumLevels(buf []byte, levels []Level) []byte {
+	if len(levels) > checksumDepth {
+		levels = levels[:checksumDepth]
+	}
+	for _, level := range levels {
+		var price [8]byte
+		ByteOrder.PutUint64(price[:], math.Float64bits(level.Price))
+		buf = append(buf, price[:]...)
+		var volume [4]byte
+		ByteOrder.PutUint32(volume[:], level.Volume)
+		buf = append(buf, volume[:]...)
+	}
+	return buf
+}
+
+// PackBookChecksum packs a single checksum message for one instrument.
+func PackBookChecksum(tickerID uint64, checksum uint32) ([]byte, error) {
+	header := MitchHeader{
+		MessageType: MsgTypeBookChecksum,
+		Timestamp:   WriteTimestamp48(nanosSinceMidnight(time.Now())),
+		Count:       1,
+	}
+	body := BookChecksumBody{TickerID: tickerID, Checksum: checksum}
+
+	data := make([]byte, HeaderSize+BookChecksumBodySize)
+	packHeaderInto(data, header)
+	encoded, _ := body.MarshalBinary()
+	copy(data[HeaderSize:], encoded)
+	return data, nil
+}
+
+// UnpackBookChecksum deserializes a book checksum message.
+func UnpackBookChecksum(data []byte) (*MitchHeader, *BookChecksumBody, error) {
+	if len(data) < HeaderSize+BookChecksumBodySize {
+		return nil, nil, fmt.Errorf("mitch: %w: book checksum message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize+BookChecksumBodySize)
+	}
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeBookChecksum {
+		return nil, nil, fmt.Errorf("mitch: message type %q is not a book checksum", header.MessageType)
+	}
+	body := new(BookChecksumBody)
+	if err := body.UnmarshalBinary(data[HeaderSize:]); err != nil {
+		return nil, nil, err
+	}
+	return header, body, nil
+}
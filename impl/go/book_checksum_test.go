@@ -0,0 +1,87 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBookChecksumDeterministic(t *testing.T) {
+	bids := []Level{{Price: 99.5, Volume: 10}, {Price: 99.0, Volume: 20}}
+	asks := []Level{{Price: 100.0, Volume: 5}, {Price: 100.5, Volume: 15}}
+
+	first := BookChecksum(bids, asks)
+	if second := BookChecksum(bids, asks); second != first {
+		t.Errorf("checksum not deterministic: %08X vs %08X", first, second)
+	}
+	if BookChecksum(asks, bids) == first {
+		t.Error("swapping sides must change the checksum")
+	}
+
+	changed := []Level{{Price: 99.5, Volume: 11}, {Price: 99.0, Volume: 20}}
+	if BookChecksum(changed, asks) == first {
+		t.Error("a volume change in the top levels must change the checksum")
+	}
+}
+
+func TestBookChecksumTopLevelsOnly(t *testing.T) {
+	var bids, asks []Level
+	for i := 0; i < checksumDepth+5; i++ {
+		bids = append(bids, Level{Price: 100 - float64(i), Volume: 1})
+		asks = append(asks, Level{Price: 101 + float64(i), Volume: 1})
+	}
+	base := BookChecksum(bids, asks)
+
+	// Churning a level beyond checksumDepth must not affect the checksum.
+	bids[checksumDepth].Volume = 999
+	if BookChecksum(bids, asks) != base {
+		t.Error("deep levels must not participate in the checksum")
+	}
+}
+
+func TestBookChecksumAgainstMaintainedBook(t *testing.T) {
+	book := NewBook()
+	update := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: SideSell}
+	book.Apply(&update, []uint32{5, 0, 7})
+
+	bids, asks := book.Snapshot()
+	checksum := BookChecksum(bids, asks)
+
+	frame, err := PackBookChecksum(1, checksum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, body, err := UnpackBookChecksum(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.TickerID != 1 || body.Checksum != checksum {
+		t.Errorf("round trip = %+v, want ticker 1 checksum %08X", body, checksum)
+	}
+}
+
+func TestBookChecksumThroughDecoder(t *testing.T) {
+	frame, err := PackBookChecksum(42, 0xDEADBEEF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, bodies, err := NewDecoder(bytes.NewReader(frame)).Decode()
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if header.MessageType != MsgTypeBookChecksum {
+		t.Errorf("type = %q, want bookchecksum", header.MessageType)
+	}
+	body := bodies[0].(*BookChecksumBody)
+	if body.TickerID != 42 || body.Checksum != 0xDEADBEEF {
+		t.Errorf("body = %+v", body)
+	}
+
+	_, anyBodies, err := UnpackAny(frame)
+	if err != nil {
+		t.Fatalf("UnpackAny: %v", err)
+	}
+	if got := anyBodies[0].(*BookChecksumBody); got.Checksum != 0xDEADBEEF {
+		t.Errorf("UnpackAny checksum = %08X", got.Checksum)
+	}
+}
@@ -0,0 +1,43 @@
+// Semantic book comparison for reconstruction tests.
+//
+// Byte-level equality is too strict for comparing a rebuilt book against a
+// reference: FirstTick and TickSize travel through float64 arithmetic
+// (mid-price math, delta application) and can pick up representation noise
+// without the books differing in any meaningful way. BookEqual compares
+// what the book means — instrument, side, price grid, per-level volumes —
+// with the same relative tolerance the trade classifier uses for prices.
+
+package mitch
+
+// BookEqual reports whether two books describe the same market state:
+// same TickerID and Side, FirstTick and TickSize equal within the
+// package's relative price tolerance (classifyTolerance, 1e-9), and the
+// same volume at every level. Depth is taken as the smaller of NumTicks
+// and the volume slice, mirroring TruncateBook. Padding is ignored.
+func BookEqual(a OrderBookBody, av []uint32, b OrderBookBody, bv []uint32) bool {
+	if a.TickerID != b.TickerID || a.Side != b.Side {
+		return false
+	}
+	if !priceEq(a.FirstTick, b.FirstTick) || !priceEq(a.TickSize, b.TickSize) {
+		return false
+	}
+	if bookDepth(a, av) != bookDepth(b, bv) {
+		return false
+	}
+	for i := 0; i < bookDepth(a, av); i++ {
+		if av[i] != bv[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bookDepth returns the usable level count: NumTicks capped by the
+// volumes actually supplied.
+func bookDepth(b OrderBookBody, volumes []uint32) int {
+	depth := int(b.NumTicks)
+	if len(volumes) < depth {
+		depth = len(volumes)
+	}
+	return depth
+}
@@ -0,0 +1,85 @@
+package mitch
+
+import "testing"
+
+func TestBookEqual(t *testing.T) {
+	base := OrderBookBody{TickerID: 7, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: BookSideBid}
+	volumes := []uint32{10, 20, 30}
+
+	if !BookEqual(base, volumes, base, []uint32{10, 20, 30}) {
+		t.Error("identical books compared unequal")
+	}
+
+	// Representation noise within the tolerance still compares equal.
+	noisy := base
+	noisy.FirstTick = 100 * (1 + 1e-12)
+	noisy.TickSize = 0.5 * (1 - 1e-12)
+	if !BookEqual(base, volumes, noisy, volumes) {
+		t.Error("sub-tolerance float noise compared unequal")
+	}
+
+	// Padding carries no market state.
+	padded := base
+	padded.Padding = [5]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	if !BookEqual(base, volumes, padded, volumes) {
+		t.Error("padding difference compared unequal")
+	}
+}
+
+func TestBookEqualDifferences(t *testing.T) {
+	base := OrderBookBody{TickerID: 7, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: BookSideBid}
+	volumes := []uint32{10, 20, 30}
+
+	cases := []struct {
+		name string
+		b    func() (OrderBookBody, []uint32)
+	}{
+		{"ticker", func() (OrderBookBody, []uint32) {
+			b := base
+			b.TickerID = 8
+			return b, volumes
+		}},
+		{"side", func() (OrderBookBody, []uint32) {
+			b := base
+			b.Side = BookSideAsk
+			return b, volumes
+		}},
+		{"first tick", func() (OrderBookBody, []uint32) {
+			b := base
+			b.FirstTick = 100.5
+			return b, volumes
+		}},
+		{"tick size", func() (OrderBookBody, []uint32) {
+			b := base
+			b.TickSize = 0.25
+			return b, volumes
+		}},
+		{"depth", func() (OrderBookBody, []uint32) {
+			b := base
+			b.NumTicks = 2
+			return b, volumes[:2]
+		}},
+		{"volume", func() (OrderBookBody, []uint32) {
+			return base, []uint32{10, 21, 30}
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, bv := tc.b()
+			if BookEqual(base, volumes, b, bv) {
+				t.Error("differing books compared equal")
+			}
+		})
+	}
+}
+
+func TestBookEqualDepthCapping(t *testing.T) {
+	// NumTicks beyond the supplied volumes caps at the slice length, so a
+	// truncated slice with a stale NumTicks still compares by real depth.
+	a := OrderBookBody{TickerID: 1, FirstTick: 10, TickSize: 1, NumTicks: 5, Side: BookSideAsk}
+	b := a
+	b.NumTicks = 2
+	if !BookEqual(a, []uint32{4, 5}, b, []uint32{4, 5}) {
+		t.Error("capped depths compared unequal")
+	}
+}
@@ -0,0 +1,169 @@
+package mitch
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBookApplyAndSnapshot(t *testing.T) {
+	book := NewBook()
+	// Asks ascend from FirstTick.
+	book.Apply(&OrderBookBody{FirstTick: 100.0, TickSize: 0.5, NumTicks: 3, Side: SideSell},
+		[]uint32{10, 20, 30})
+	// Bids descend from FirstTick.
+	book.Apply(&OrderBookBody{FirstTick: 99.5, TickSize: 0.5, NumTicks: 2, Side: SideBuy},
+		[]uint32{5, 6})
+
+	bids, asks := book.Snapshot()
+	if len(bids) != 2 || len(asks) != 3 {
+		t.Fatalf("depth = (%d, %d), want (2, 3)", len(bids), len(asks))
+	}
+	if bids[0] != (Level{99.5, 5}) || bids[1] != (Level{99.0, 6}) {
+		t.Errorf("bids = %v", bids)
+	}
+	if asks[0] != (Level{100.0, 10}) || asks[2] != (Level{101.0, 30}) {
+		t.Errorf("asks = %v", asks)
+	}
+}
+
+func TestBookZeroVolumeRemoves(t *testing.T) {
+	book := NewBook()
+	book.Apply(&OrderBookBody{FirstTick: 100.0, TickSize: 1, NumTicks: 3, Side: SideSell},
+		[]uint32{10, 20, 30})
+	// Overlapping update clears the middle level and changes the first.
+	book.Apply(&OrderBookBody{FirstTick: 100.0, TickSize: 1, NumTicks: 2, Side: SideSell},
+		[]uint32{11, 0})
+
+	_, asks := book.Snapshot()
+	if len(asks) != 2 {
+		t.Fatalf("asks = %v, want two levels", asks)
+	}
+	if asks[0] != (Level{100.0, 11}) || asks[1] != (Level{102.0, 30}) {
+		t.Errorf("asks = %v", asks)
+	}
+}
+
+func TestBookPartialOverlap(t *testing.T) {
+	book := NewBook()
+	book.Apply(&OrderBookBody{FirstTick: 100.0, TickSize: 1, NumTicks: 2, Side: SideSell},
+		[]uint32{1, 2})
+	// Second update overlaps the tail and extends beyond it.
+	book.Apply(&OrderBookBody{FirstTick: 101.0, TickSize: 1, NumTicks: 2, Side: SideSell},
+		[]uint32{9, 4})
+
+	_, asks := book.Snapshot()
+	want := []Level{{100.0, 1}, {101.0, 9}, {102.0, 4}}
+	if len(asks) != len(want) {
+		t.Fatalf("asks = %v, want %v", asks, want)
+	}
+	for i := range want {
+		if asks[i] != want[i] {
+			t.Errorf("asks[%d] = %v, want %v", i, asks[i], want[i])
+		}
+	}
+}
+
+func TestBookConcurrentReaders(t *testing.T) {
+	book := NewBook()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			book.Apply(&OrderBookBody{FirstTick: 100.0, TickSize: 0.5, NumTicks: 4, Side: SideSell},
+				[]uint32{uint32(i), uint32(i + 1), 0, uint32(i + 2)})
+		}
+		close(done)
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					book.Snapshot()
+					book.Depth()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBookIsCrossed(t *testing.T) {
+	book := NewBook()
+	bids := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 2, Side: SideBuy}
+	asks := OrderBookBody{TickerID: 1, FirstTick: 101, TickSize: 1, NumTicks: 2, Side: SideSell}
+	book.Apply(&bids, []uint32{10, 10})
+	book.Apply(&asks, []uint32{10, 10})
+	if book.IsCrossed() {
+		t.Error("bid 100 / ask 101 reported as crossed")
+	}
+
+	// An ask below the best bid crosses the book.
+	lowAsk := OrderBookBody{TickerID: 1, FirstTick: 99, TickSize: 1, NumTicks: 1, Side: SideSell}
+	book.Apply(&lowAsk, []uint32{5})
+	if !book.IsCrossed() {
+		t.Error("bid 100 / ask 99 not reported as crossed")
+	}
+
+	if NewBook().IsCrossed() {
+		t.Error("empty book reported as crossed")
+	}
+}
+
+func TestBookOnCrossCallback(t *testing.T) {
+	book := NewBook()
+	var gotBid, gotAsk float64
+	calls := 0
+	book.OnCross = func(bestBid, bestAsk float64) {
+		gotBid, gotAsk = bestBid, bestAsk
+		calls++
+	}
+
+	bids := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 1, Side: SideBuy}
+	book.Apply(&bids, []uint32{10})
+	if calls != 0 {
+		t.Fatal("OnCross fired for a one-sided book")
+	}
+
+	lowAsk := OrderBookBody{TickerID: 1, FirstTick: 98, TickSize: 1, NumTicks: 1, Side: SideSell}
+	book.Apply(&lowAsk, []uint32{5})
+	if calls != 1 || gotBid != 100 || gotAsk != 98 {
+		t.Errorf("OnCross: calls=%d bid=%v ask=%v, want 1, 100, 98", calls, gotBid, gotAsk)
+	}
+}
+
+func TestBookDropCrossing(t *testing.T) {
+	book := NewBook()
+	book.DropCrossing = true
+
+	bids := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 1, Side: SideBuy}
+	asks := OrderBookBody{TickerID: 1, FirstTick: 101, TickSize: 1, NumTicks: 1, Side: SideSell}
+	book.Apply(&bids, []uint32{10})
+	book.Apply(&asks, []uint32{20})
+
+	// A crossing update is dropped; the previous state survives.
+	lowAsk := OrderBookBody{TickerID: 1, FirstTick: 99, TickSize: 1, NumTicks: 1, Side: SideSell}
+	book.Apply(&lowAsk, []uint32{5})
+	if book.IsCrossed() {
+		t.Error("crossing update was applied despite DropCrossing")
+	}
+	_, askLevels := book.Snapshot()
+	if len(askLevels) != 1 || askLevels[0].Price != 101 || askLevels[0].Volume != 20 {
+		t.Errorf("asks after dropped update = %+v, want the original 101 x 20", askLevels)
+	}
+
+	// The same policy guards deltas.
+	book.ApplyDelta(&OrderBookDelta{
+		Book:    OrderBookBody{TickerID: 1, FirstTick: 99, TickSize: 1, NumTicks: 1, Side: SideSell},
+		Changes: []DeltaLevel{{Index: 0, Volume: 7}},
+	})
+	if book.IsCrossed() {
+		t.Error("crossing delta was applied despite DropCrossing")
+	}
+}
@@ -0,0 +1,140 @@
+// Explicit byte order variants for non-spec tooling.
+//
+// The MITCH wire format is big-endian, but internal tooling sometimes
+// produces little-endian capture files. These variants take an explicit
+// binary.ByteOrder so such dumps can be packed and decoded; the plain
+// functions delegate here with the package default and stay spec-compliant.
+
+package mitch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// PackMessageOrdered packs like PackMessage using an explicit byte order.
+func PackMessageOrdered(order binary.ByteOrder, messageType MessageType, bodies ...interface{}) ([]byte, error) {
+	return packMessageOrderedAt(order, time.Now(), messageType, bodies...)
+}
+
+// packMessageOrderedAt is the shared packing core: explicit byte order and
+// explicit header timestamp.
+func packMessageOrderedAt(order binary.ByteOrder, ts time.Time, messageType MessageType, bodies ...interface{}) ([]byte, error) {
+	if len(bodies) == 0 {
+		return nil, fmt.Errorf("mitch: %w to pack", ErrNoBodies)
+	}
+	if len(bodies) > 255 {
+		return nil, fmt.Errorf("mitch: %w: %d (max 255)", ErrTooManyBodies, len(bodies))
+	}
+
+	header := MitchHeader{
+		MessageType: messageType,
+		Timestamp:   WriteTimestamp48(nanosSinceMidnight(ts)),
+		Count:       uint8(len(bodies)),
+	}
+
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, order, header); err != nil {
+		return nil, fmt.Errorf("mitch: pack header: %w", err)
+	}
+	for i, body := range bodies {
+		if err := binary.Write(buf, order, sanitizeBody(body)); err != nil {
+			return nil, fmt.Errorf("mitch: pack body %d: %w", i, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnpackMessageOrdered unpacks like UnpackMessage using an explicit byte
+// order, for decoding captured non-spec dumps.
+func UnpackMessageOrdered(order binary.ByteOrder, data []byte) (*MitchHeader, []interface{}, error) {
+	if len(data) < HeaderSize {
+		return nil, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+
+	header := new(MitchHeader)
+	if err := binary.Read(bytes.NewReader(data[:HeaderSize]), order, header); err != nil {
+		return nil, nil, fmt.Errorf("mitch: unpack header: %w", err)
+	}
+
+	if header.MessageType == MsgTypeOrderBook {
+		return nil, nil, fmt.Errorf("mitch: %w; see UnpackOrderBook", ErrOrderBookNeedsSpecialUnpack)
+	}
+
+	// The fixed types all carry 32-byte bodies, so the exact frame length
+	// is known from Count alone. Checking it up front turns a truncated
+	// buffer into one precise error instead of an "unexpected EOF" partway
+	// through the body loop.
+	switch header.MessageType {
+	case MsgTypeTrade, MsgTypeOrder, MsgTypeTicker:
+		expected := HeaderSize + int(header.Count)*BodySizeFixed
+		if len(data) < expected {
+			return nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d bodies, got %d", ErrShortBuffer, expected, header.Count, len(data))
+		}
+		if len(data) > expected {
+			return nil, nil, fmt.Errorf("mitch: expected %d bytes for %d bodies, got %d (trailing bytes)", expected, header.Count, len(data))
+		}
+	}
+
+	reader := bytes.NewReader(data[HeaderSize:])
+	bodies := make([]interface{}, 0, header.Count)
+	for i := 0; i < int(header.Count); i++ {
+		var body interface{}
+		switch header.MessageType {
+		case MsgTypeTrade:
+			body = new(TradeBody)
+		case MsgTypeOrder:
+			body = new(OrderBody)
+		case MsgTypeTicker:
+			body = new(TickerBody)
+		default:
+			return nil, nil, fmt.Errorf("mitch: %w 0x%02X", ErrUnknownMessageType, header.MessageType)
+		}
+		if err := binary.Read(reader, order, body); err != nil {
+			return nil, nil, fmt.Errorf("mitch: unpack body %d: %w", i, err)
+		}
+		bodies = append(bodies, body)
+	}
+	return header, bodies, nil
+}
+
+// UnpackOrderBookOrdered unpacks a complete order book message using an
+// explicit byte order.
+func UnpackOrderBookOrdered(order binary.ByteOrder, data []byte) (*MitchHeader, *OrderBookBody, []uint32, error) {
+	if len(data) < HeaderSize+BodySizeFixed {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: order book message is %d bytes (need at least %d)", ErrShortBuffer, len(data), HeaderSize+BodySizeFixed)
+	}
+
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeOrderBook {
+		return nil, nil, nil, fmt.Errorf("mitch: message type %q is not an order book", header.MessageType)
+	}
+
+	raw := data[HeaderSize:]
+	book := &OrderBookBody{
+		TickerID:  order.Uint64(raw[0:8]),
+		FirstTick: math.Float64frombits(order.Uint64(raw[8:16])),
+		TickSize:  math.Float64frombits(order.Uint64(raw[16:24])),
+		NumTicks:  order.Uint16(raw[24:26]),
+		Side:      Side(raw[26]),
+	}
+	copy(book.Padding[:], raw[27:32])
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return nil, nil, nil, err
+	}
+
+	expected := HeaderSize + BodySizeFixed + int(book.NumTicks)*4
+	if len(data) < expected {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d ticks, got %d", ErrShortBuffer, expected, book.NumTicks, len(data))
+	}
+	volumes := make([]uint32, book.NumTicks)
+	off := HeaderSize + BodySizeFixed
+	for i := range volumes {
+		volumes[i] = order.Uint32(data[off : off+4])
+		off += 4
+	}
+	return header, book, volumes, nil
+}
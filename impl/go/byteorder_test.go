@@ -0,0 +1,68 @@
+package mitch
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestPackMessageOrderedLittleEndian(t *testing.T) {
+	trade := TradeBody{TickerID: 0x0102030405060708, Price: 1.5, Quantity: 9, TradeID: 0xAABBCCDD}
+
+	message, err := PackMessageOrdered(binary.LittleEndian, MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatalf("PackMessageOrdered: %v", err)
+	}
+	// TickerID at body offset 0 must come out least-significant byte first.
+	if message[HeaderSize] != 0x08 || message[HeaderSize+7] != 0x01 {
+		t.Errorf("TickerID bytes = % X, want little-endian", message[HeaderSize:HeaderSize+8])
+	}
+
+	_, bodies, err := UnpackMessageOrdered(binary.LittleEndian, message)
+	if err != nil {
+		t.Fatalf("UnpackMessageOrdered: %v", err)
+	}
+	if got := *bodies[0].(*TradeBody); got != trade {
+		t.Errorf("round trip = %+v, want %+v", got, trade)
+	}
+}
+
+func TestDefaultStaysBigEndian(t *testing.T) {
+	trade := TradeBody{TickerID: 0x0102030405060708}
+	message, err := PackMessage(MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatalf("PackMessage: %v", err)
+	}
+	if message[HeaderSize] != 0x01 || message[HeaderSize+7] != 0x08 {
+		t.Errorf("TickerID bytes = % X, want big-endian", message[HeaderSize:HeaderSize+8])
+	}
+}
+
+func TestUnpackOrderBookOrdered(t *testing.T) {
+	book := OrderBookBody{TickerID: 5, FirstTick: 10, TickSize: 0.5, NumTicks: 2, Side: SideSell}
+	volumes := []uint32{3, 4}
+
+	// Build a little-endian capture by hand: big-endian pack, then re-read
+	// through the ordered unpacker after swapping via a little-endian pack.
+	data := make([]byte, HeaderSize+BodySizeFixed+8)
+	data[0] = byte(MsgTypeOrderBook)
+	data[7] = 1
+	le := binary.LittleEndian
+	le.PutUint64(data[HeaderSize:], book.TickerID)
+	le.PutUint64(data[HeaderSize+8:], 0x4024000000000000)  // 10.0
+	le.PutUint64(data[HeaderSize+16:], 0x3FE0000000000000) // 0.5
+	le.PutUint16(data[HeaderSize+24:], book.NumTicks)
+	data[HeaderSize+26] = byte(book.Side)
+	le.PutUint32(data[HeaderSize+32:], volumes[0])
+	le.PutUint32(data[HeaderSize+36:], volumes[1])
+
+	_, gotBook, gotVolumes, err := UnpackOrderBookOrdered(le, data)
+	if err != nil {
+		t.Fatalf("UnpackOrderBookOrdered: %v", err)
+	}
+	if *gotBook != book {
+		t.Errorf("book = %+v, want %+v", *gotBook, book)
+	}
+	if gotVolumes[0] != 3 || gotVolumes[1] != 4 {
+		t.Errorf("volumes = %v, want [3 4]", gotVolumes)
+	}
+}
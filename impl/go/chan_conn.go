@@ -0,0 +1,184 @@
+// In-memory net.Conn for pipeline tests.
+//
+// net.Pipe works but is fully synchronous — every Write blocks until the
+// peer reads — which makes some test choreography awkward and deadlocks
+// easy. ChanConn is a buffered channel-backed net.Conn pair, so tests can
+// exercise the Decoder, Fanout and Client against realistic asynchrony
+// without sockets, and its deadline support means timeout paths are
+// testable too.
+
+package mitch
+
+import (
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// chanConnBuffer is how many writes a ChanConn holds before Write blocks.
+const chanConnBuffer = 64
+
+// chanAddr is the placeholder address of an in-memory connection.
+type chanAddr struct{}
+
+func (chanAddr) Network() string { return "chan" }
+func (chanAddr) String() string  { return "chan" }
+
+// ChanConn is one end of an in-memory, channel-backed connection. Create
+// pairs with NewChanConnPair.
+type ChanConn struct {
+	recv <-chan []byte
+	send chan<- []byte
+	done chan struct{}
+
+	mu            sync.Mutex
+	closed        bool
+	leftover      []byte
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+// NewChanConnPair returns two connected in-memory connections: bytes
+// written to one are read from the other. Each direction buffers up to
+// chanConnBuffer writes before blocking.
+func NewChanConnPair() (*ChanConn, *ChanConn) {
+	ab := make(chan []byte, chanConnBuffer)
+	ba := make(chan []byte, chanConnBuffer)
+	done := make(chan struct{})
+	a := &ChanConn{recv: ba, send: ab, done: done}
+	b := &ChanConn{recv: ab, send: ba, done: done}
+	return a, b
+}
+
+// Read reads from the peer's writes, honouring the read deadline. A
+// closed connection reads io.EOF once buffered data is drained.
+func (c *ChanConn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	if len(c.leftover) > 0 {
+		n := copy(p, c.leftover)
+		c.leftover = c.leftover[n:]
+		c.mu.Unlock()
+		return n, nil
+	}
+	deadline := c.readDeadline
+	c.mu.Unlock()
+
+	timeout, expired := deadlineChan(deadline)
+	if expired {
+		return 0, os.ErrDeadlineExceeded
+	}
+	select {
+	case chunk := <-c.recv:
+		n := copy(p, chunk)
+		if n < len(chunk) {
+			c.mu.Lock()
+			c.leftover = chunk[n:]
+			c.mu.Unlock()
+		}
+		return n, nil
+	case <-c.done:
+		// Drain anything written before the close.
+		select {
+		case chunk := <-c.recv:
+			n := copy(p, chunk)
+			if n < len(chunk) {
+				c.mu.Lock()
+				c.leftover = chunk[n:]
+				c.mu.Unlock()
+			}
+			return n, nil
+		default:
+			return 0, io.EOF
+		}
+	case <-timeout:
+		return 0, os.ErrDeadlineExceeded
+	}
+}
+
+// Write sends p to the peer, honouring the write deadline. The slice is
+// copied, so the caller may reuse it immediately.
+func (c *ChanConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	deadline := c.writeDeadline
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	timeout, expired := deadlineChan(deadline)
+	if expired {
+		return 0, os.ErrDeadlineExceeded
+	}
+	chunk := append([]byte(nil), p...)
+	select {
+	case c.send <- chunk:
+		return len(p), nil
+	case <-c.done:
+		return 0, io.ErrClosedPipe
+	case <-timeout:
+		return 0, os.ErrDeadlineExceeded
+	}
+}
+
+// Close closes both directions of the pair; blocked Reads and Writes on
+// either end unblock.
+func (c *ChanConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		select {
+		case <-c.done:
+		default:
+			close(c.done)
+		}
+	}
+	return nil
+}
+
+// LocalAddr returns a placeholder address.
+func (c *ChanConn) LocalAddr() net.Addr { return chanAddr{} }
+
+// RemoteAddr returns a placeholder address.
+func (c *ChanConn) RemoteAddr() net.Addr { return chanAddr{} }
+
+// SetDeadline sets both read and write deadlines.
+func (c *ChanConn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	c.writeDeadline = t
+	return nil
+}
+
+// SetReadDeadline sets the read deadline; the zero time means no limit.
+func (c *ChanConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+// SetWriteDeadline sets the write deadline; the zero time means no limit.
+func (c *ChanConn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeDeadline = t
+	return nil
+}
+
+// deadlineChan turns a deadline into a timer channel: nil (never fires)
+// for the zero time, expired=true for a deadline already in the past.
+func deadlineChan(deadline time.Time) (<-chan time.Time, bool) {
+	if deadline.IsZero() {
+		return nil, false
+	}
+	wait := time.Until(deadline)
+	if wait <= 0 {
+		return nil, true
+	}
+	return time.After(wait), false
+}
@@ -0,0 +1,123 @@
+package mitch
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestChanConnDecoderRoundTrip(t *testing.T) {
+	client, server := NewChanConnPair()
+	defer client.Close()
+
+	trade := TradeBody{TickerID: 99, Price: 101.5, Quantity: 3, Side: SideBuy}
+	packed, err := PackMessage(MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		server.Write(packed)
+		server.Close()
+	}()
+
+	decoder := NewDecoder(client)
+	header, bodies, err := decoder.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.MessageType != MsgTypeTrade || len(bodies) != 1 {
+		t.Fatalf("decoded header %+v with %d bodies", header, len(bodies))
+	}
+	if got := bodies[0].(*TradeBody); got.TickerID != 99 {
+		t.Fatalf("decoded %+v", got)
+	}
+	if _, _, err := decoder.Decode(); !errors.Is(err, io.EOF) {
+		t.Errorf("after close, err = %v, want io.EOF", err)
+	}
+}
+
+func TestChanConnReadDeadline(t *testing.T) {
+	client, server := NewChanConnPair()
+	defer client.Close()
+	defer server.Close()
+
+	client.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	_, err := client.Read(make([]byte, 8))
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("Read past deadline = %v, want a net.Error timeout", err)
+	}
+
+	// Clearing the deadline makes reads block again.
+	client.SetReadDeadline(time.Time{})
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		server.Write([]byte{1, 2, 3})
+	}()
+	buf := make([]byte, 3)
+	if _, err := io.ReadFull(client, buf); err != nil {
+		t.Fatalf("Read after clearing deadline: %v", err)
+	}
+}
+
+func TestChanConnPartialReads(t *testing.T) {
+	client, server := NewChanConnPair()
+	defer client.Close()
+	defer server.Close()
+
+	if _, err := server.Write([]byte{10, 20, 30, 40}); err != nil {
+		t.Fatal(err)
+	}
+	one := make([]byte, 1)
+	for i, want := range []byte{10, 20, 30, 40} {
+		n, err := client.Read(one)
+		if err != nil || n != 1 || one[0] != want {
+			t.Fatalf("read %d = (%d, %v, %d), want byte %d", i, n, err, one[0], want)
+		}
+	}
+}
+
+func TestChanConnWriteDeadline(t *testing.T) {
+	client, server := NewChanConnPair()
+	defer client.Close()
+	defer server.Close()
+
+	// Fill the buffered direction so the next Write must block.
+	for i := 0; i < chanConnBuffer; i++ {
+		if _, err := client.Write([]byte{0}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client.SetWriteDeadline(time.Now().Add(10 * time.Millisecond))
+	_, err := client.Write([]byte{0})
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("blocked Write = %v, want a net.Error timeout", err)
+	}
+}
+
+func TestChanConnCloseUnblocksPeer(t *testing.T) {
+	client, server := NewChanConnPair()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := server.Read(make([]byte, 8))
+		errs <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	client.Close()
+	select {
+	case err := <-errs:
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("Read on closed pair = %v, want io.EOF", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read did not unblock after Close")
+	}
+
+	if _, err := client.Write([]byte{0}); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Write after Close = %v, want io.ErrClosedPipe", err)
+	}
+}
@@ -0,0 +1,63 @@
+// CRC32 message integrity trailer.
+//
+// For forwarding MITCH over lossy or corruptible channels: a 4-byte CRC32
+// (Castagnoli) trailer is appended after the bodies, computed over the
+// header and bodies. Like the length-prefixed framing this is a transport
+// wrapper outside the MITCH spec.
+
+package mitch
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+// ChecksumSize is the size of the CRC32 trailer in bytes.
+const ChecksumSize = 4
+
+// castagnoli is the CRC32-C table shared by all checksum operations.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// PackWithChecksum packs the bodies like PackMessage and appends a 4-byte
+// big-endian CRC32-C trailer computed over the header and bodies.
+func PackWithChecksum(messageType MessageType, bodies ...interface{}) ([]byte, error) {
+	message, err := PackMessage(messageType, bodies...)
+	if err != nil {
+		return nil, err
+	}
+	return AppendChecksum(message), nil
+}
+
+// AppendChecksum appends a 4-byte big-endian CRC32-C trailer covering all
+// of message, for callers that pack via other paths (e.g. PackOrderBook).
+func AppendChecksum(message []byte) []byte {
+	sum := crc32.Checksum(message, castagnoli)
+	var trailer [ChecksumSize]byte
+	ByteOrder.PutUint32(trailer[:], sum)
+	return append(message, trailer[:]...)
+}
+
+// VerifyChecksum recomputes the CRC32-C over everything before the 4-byte
+// trailer and compares it to the trailer. It errors rather than panicking
+// when data is shorter than the trailer itself.
+func VerifyChecksum(data []byte) (bool, error) {
+	if len(data) < ChecksumSize {
+		return false, fmt.Errorf("mitch: %w: data is %d bytes, checksum trailer needs %d", ErrShortBuffer, len(data), ChecksumSize)
+	}
+	payload := data[:len(data)-ChecksumSize]
+	want := ByteOrder.Uint32(data[len(data)-ChecksumSize:])
+	return crc32.Checksum(payload, castagnoli) == want, nil
+}
+
+// StripChecksum verifies the trailer and returns the inner message without
+// it, erroring on a mismatch.
+func StripChecksum(data []byte) ([]byte, error) {
+	ok, err := VerifyChecksum(data)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("mitch: %w", ErrChecksumMismatch)
+	}
+	return data[:len(data)-ChecksumSize], nil
+}
@@ -0,0 +1,50 @@
+package mitch
+
+import "testing"
+
+func TestChecksumRoundTrip(t *testing.T) {
+	data, err := PackWithChecksum(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 10, Quantity: 5, TradeID: 2, Side: SideBuy})
+	if err != nil {
+		t.Fatalf("PackWithChecksum failed: %v", err)
+	}
+	ok, err := VerifyChecksum(data)
+	if err != nil {
+		t.Fatalf("VerifyChecksum failed: %v", err)
+	}
+	if !ok {
+		t.Error("checksum did not verify on untouched data")
+	}
+
+	inner, err := StripChecksum(data)
+	if err != nil {
+		t.Fatalf("StripChecksum failed: %v", err)
+	}
+	if _, _, err := UnpackMessage(inner); err != nil {
+		t.Errorf("inner message failed to unpack: %v", err)
+	}
+}
+
+func TestChecksumDetectsCorruption(t *testing.T) {
+	data, err := PackWithChecksum(MsgTypeTicker, TickerBody{TickerID: 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[HeaderSize] ^= 0x01 // flip one body bit
+	ok, err := VerifyChecksum(data)
+	if err != nil {
+		t.Fatalf("VerifyChecksum failed: %v", err)
+	}
+	if ok {
+		t.Error("checksum verified on corrupted data")
+	}
+	if _, err := StripChecksum(data); err == nil {
+		t.Error("StripChecksum accepted corrupted data")
+	}
+}
+
+func TestChecksumShortData(t *testing.T) {
+	if _, err := VerifyChecksum([]byte{1, 2}); err == nil {
+		t.Error("expected error for data shorter than the trailer")
+	}
+}
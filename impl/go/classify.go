@@ -0,0 +1,82 @@
+// Execution classification against the prevailing quote.
+//
+// Where a trade printed relative to the ticker's bid/ask says a lot about
+// execution quality: passive fills print at the bid or ask, price
+// improvement prints inside the spread, and a print outside the spread is
+// a trade-through worth flagging. Price comparisons use a relative
+// tolerance so a price that went through a float64 round trip still
+// matches its quote level exactly.
+
+package mitch
+
+import "math"
+
+// Classification is where a trade printed relative to the quote.
+type Classification uint8
+
+const (
+	// TradeUnclassified means the quote was empty or one-sided and the
+	// trade matched no present level, so no call can be made.
+	TradeUnclassified Classification = iota
+	// TradeAtBid is a print at the prevailing bid.
+	TradeAtBid
+	// TradeAtAsk is a print at the prevailing ask.
+	TradeAtAsk
+	// TradeAtMid is a print strictly inside the spread — price
+	// improvement against both quoted levels.
+	TradeAtMid
+	// TradeOutside is a print outside the spread: a trade-through.
+	TradeOutside
+)
+
+// String returns a short label for execution-quality reports.
+func (c Classification) String() string {
+	switch c {
+	case TradeAtBid:
+		return "at-bid"
+	case TradeAtAsk:
+		return "at-ask"
+	case TradeAtMid:
+		return "mid"
+	case TradeOutside:
+		return "outside"
+	}
+	return "unclassified"
+}
+
+// classifyTolerance is the relative tolerance for matching a trade price
+// to a quote level, absorbing float64 round-trip noise without conflating
+// adjacent ticks.
+const classifyTolerance = 1e-9
+
+// priceEq reports whether two prices are equal within the relative
+// classification tolerance.
+func priceEq(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	scale := math.Max(math.Abs(a), math.Abs(b))
+	return math.Abs(a-b) <= scale*classifyTolerance
+}
+
+// TradeClassification reports where the trade printed relative to the
+// concurrent quote: at the bid, at the ask, inside the spread, or outside
+// it (a trade-through). With a one-sided quote only a print at the
+// present level classifies; anything else is TradeUnclassified.
+func TradeClassification(trade *TradeBody, ticker *TickerBody) Classification {
+	hasBid := ticker.BidPrice != 0
+	hasAsk := ticker.AskPrice != 0
+	if hasBid && priceEq(trade.Price, ticker.BidPrice) {
+		return TradeAtBid
+	}
+	if hasAsk && priceEq(trade.Price, ticker.AskPrice) {
+		return TradeAtAsk
+	}
+	if !hasBid || !hasAsk {
+		return TradeUnclassified
+	}
+	if trade.Price > ticker.BidPrice && trade.Price < ticker.AskPrice {
+		return TradeAtMid
+	}
+	return TradeOutside
+}
@@ -0,0 +1,61 @@
+package mitch
+
+import "testing"
+
+func TestTradeClassification(t *testing.T) {
+	quote := TickerBody{TickerID: 1, BidPrice: 99.5, AskPrice: 100.5}
+	cases := []struct {
+		name  string
+		price float64
+		want  Classification
+	}{
+		{"at bid", 99.5, TradeAtBid},
+		{"at ask", 100.5, TradeAtAsk},
+		{"midpoint", 100.0, TradeAtMid},
+		{"inside but off-mid", 99.7, TradeAtMid},
+		{"below bid", 99.0, TradeOutside},
+		{"above ask", 101.0, TradeOutside},
+		// A price within float round-trip noise of a level matches it.
+		{"bid plus epsilon", 99.5 * (1 + 1e-12), TradeAtBid},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			trade := TradeBody{TickerID: 1, Price: tc.price, Side: SideBuy}
+			if got := TradeClassification(&trade, &quote); got != tc.want {
+				t.Errorf("price %v = %v, want %v", tc.price, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTradeClassificationOneSidedQuote(t *testing.T) {
+	bidOnly := TickerBody{TickerID: 1, BidPrice: 50}
+	atBid := TradeBody{Price: 50}
+	if got := TradeClassification(&atBid, &bidOnly); got != TradeAtBid {
+		t.Errorf("print at lone bid = %v, want at-bid", got)
+	}
+	away := TradeBody{Price: 51}
+	if got := TradeClassification(&away, &bidOnly); got != TradeUnclassified {
+		t.Errorf("print away from lone bid = %v, want unclassified", got)
+	}
+
+	empty := TickerBody{TickerID: 1}
+	if got := TradeClassification(&away, &empty); got != TradeUnclassified {
+		t.Errorf("empty quote = %v, want unclassified", got)
+	}
+}
+
+func TestClassificationString(t *testing.T) {
+	pairs := map[Classification]string{
+		TradeAtBid:        "at-bid",
+		TradeAtAsk:        "at-ask",
+		TradeAtMid:        "mid",
+		TradeOutside:      "outside",
+		TradeUnclassified: "unclassified",
+	}
+	for c, want := range pairs {
+		if got := c.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", c, got, want)
+		}
+	}
+}
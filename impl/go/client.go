@@ -0,0 +1,187 @@
+// Reconnecting TCP client.
+//
+// Forwarder connections drop routinely and every consumer was carrying its
+// own reconnect loop. Client owns the connection lifecycle: it dials,
+// decodes messages onto a channel, and on any read failure redials with
+// exponential backoff. After each successful reconnect it emits a sentinel
+// event so consumers know their state is stale and can request a fresh
+// snapshot.
+
+package mitch
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// DecodedMessage is one event delivered by Client.Messages: either a
+// parsed message, or a Reconnected sentinel after the client re-established
+// a dropped connection (Header and Bodies are nil on the sentinel).
+type DecodedMessage struct {
+	Header      *MitchHeader
+	Bodies      []interface{}
+	Reconnected bool
+}
+
+// Client is a TCP consumer that survives transient disconnects. Create it
+// with NewClient, start it with Dial and range over Messages.
+type Client struct {
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	dialFunc   func(addr string) (net.Conn, error)
+
+	addr     string
+	messages chan DecodedMessage
+	done     chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+	log      Logger
+}
+
+// NewClient returns an unstarted client. Backoff between reconnect
+// attempts doubles from 100ms up to 30s.
+func NewClient() *Client {
+	return &Client{
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+		dialFunc: func(addr string) (net.Conn, error) {
+			return net.Dial("tcp", addr)
+		},
+		messages: make(chan DecodedMessage, 64),
+		done:     make(chan struct{}),
+	}
+}
+
+// SetLogger routes connection lifecycle diagnostics — lost connections,
+// redial attempts, reconnects — to l. Set it before Dial; the default is
+// a no-op logger.
+func (c *Client) SetLogger(l Logger) {
+	c.log = orNop(l)
+}
+
+// logger returns the configured logger or the no-op default.
+func (c *Client) logger() Logger {
+	return orNop(c.log)
+}
+
+// Dial connects to addr and starts the receive loop. The initial connect
+// error is returned synchronously; failures after that trigger automatic
+// reconnection with exponential backoff until Close is called.
+func (c *Client) Dial(addr string) error {
+	conn, err := c.dialFunc(addr)
+	if err != nil {
+		return fmt.Errorf("mitch: dial %s: %w", addr, err)
+	}
+	c.addr = addr
+	c.wg.Add(1)
+	go c.run(conn)
+	return nil
+}
+
+// Messages returns the channel of decoded messages and reconnect
+// sentinels. It is closed after Close.
+func (c *Client) Messages() <-chan DecodedMessage {
+	return c.messages
+}
+
+// Close stops the receive loop and closes the Messages channel.
+func (c *Client) Close() {
+	c.once.Do(func() { close(c.done) })
+	c.wg.Wait()
+}
+
+// run decodes messages until the connection fails, then reconnects and
+// repeats until Close.
+func (c *Client) run(conn net.Conn) {
+	defer c.wg.Done()
+	defer close(c.messages)
+	for {
+		c.receive(conn)
+		conn.Close()
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+		if !c.deliver(DecodedMessage{Reconnected: true}) {
+			conn.Close()
+			return
+		}
+	}
+}
+
+// receive decodes from one connection until it errors. A watcher closes
+// the connection when the client is closed so a blocked Decode returns
+// promptly.
+func (c *Client) receive(conn net.Conn) {
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-c.done:
+			conn.Close()
+		case <-finished:
+		}
+	}()
+
+	decoder := NewDecoder(conn)
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+		header, bodies, err := decoder.Decode()
+		if err != nil {
+			select {
+			case <-c.done:
+			default:
+				c.logger().Errorf("mitch: connection to %s lost: %v", c.addr, err)
+			}
+			return
+		}
+		if !c.deliver(DecodedMessage{Header: header, Bodies: bodies}) {
+			return
+		}
+	}
+}
+
+// reconnect redials with exponential backoff until it succeeds or the
+// client is closed, returning nil when closed.
+func (c *Client) reconnect() net.Conn {
+	backoff := c.minBackoff
+	for {
+		select {
+		case <-c.done:
+			return nil
+		default:
+		}
+		conn, err := c.dialFunc(c.addr)
+		if err == nil {
+			c.logger().Debugf("mitch: reconnected to %s", c.addr)
+			return conn
+		}
+		c.logger().Debugf("mitch: redial %s failed (%v), retrying in %v", c.addr, err, backoff)
+		select {
+		case <-c.done:
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// deliver sends one event unless the client is closed, reporting whether
+// the event was delivered.
+func (c *Client) deliver(m DecodedMessage) bool {
+	select {
+	case c.messages <- m:
+		return true
+	case <-c.done:
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+package mitch
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestClientReconnects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1, TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server accepts twice: the first connection sends one message and
+	// drops, the second sends another and stays open.
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Write(message)
+			if i == 0 {
+				conn.Close()
+			}
+		}
+	}()
+
+	client := NewClient()
+	client.minBackoff = 5 * time.Millisecond
+	if err := client.Dial(listener.Addr().String()); err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	next := func() DecodedMessage {
+		t.Helper()
+		select {
+		case m := <-client.Messages():
+			return m
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a message")
+			return DecodedMessage{}
+		}
+	}
+
+	first := next()
+	if first.Reconnected || first.Header == nil || first.Header.MessageType != MsgTypeTrade {
+		t.Fatalf("first event = %+v, want a trade message", first)
+	}
+	sentinel := next()
+	if !sentinel.Reconnected {
+		t.Fatalf("second event = %+v, want the reconnect sentinel", sentinel)
+	}
+	second := next()
+	if second.Header == nil || second.Header.MessageType != MsgTypeTrade {
+		t.Fatalf("third event = %+v, want the re-sent trade", second)
+	}
+}
+
+func TestClientDialError(t *testing.T) {
+	client := NewClient()
+	if err := client.Dial("127.0.0.1:1"); err == nil {
+		t.Error("dialing a closed port must error")
+	}
+}
+
+func TestClientCloseUnblocks(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go listener.Accept() // accept and never send
+
+	client := NewClient()
+	if err := client.Dial(listener.Addr().String()); err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		client.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not unblock the receive loop")
+	}
+
+	// The messages channel must be closed after Close.
+	if _, ok := <-client.Messages(); ok {
+		t.Error("Messages channel should be closed")
+	}
+}
@@ -0,0 +1,82 @@
+// Context-aware TCP send/receive.
+//
+// MitchSendTCP and MitchRecvMessage block until the peer cooperates, which
+// hangs a service when a broker stalls. These variants honor context
+// cancellation and deadlines by driving the connection deadline from the
+// context, so a cancellation mid-read returns promptly instead of after a
+// full message arrives.
+
+package mitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// watchContext applies the context's deadline to the connection and
+// interrupts in-flight I/O when the context is cancelled. The returned stop
+// function must be called to release the watcher and clear the deadline.
+func watchContext(ctx context.Context, conn net.Conn) func() {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Force any blocked read/write to return immediately.
+			conn.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		conn.SetDeadline(time.Time{})
+	}
+}
+
+// ctxIOError folds a context failure into an I/O error so callers see a
+// wrapped context.Canceled or context.DeadlineExceeded they can test with
+// errors.Is.
+func ctxIOError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("mitch: %w", ctxErr)
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return fmt.Errorf("mitch: %w", context.DeadlineExceeded)
+	}
+	return err
+}
+
+// MitchSendTCPContext writes a complete packed message, honoring context
+// cancellation and deadline.
+func MitchSendTCPContext(ctx context.Context, conn net.Conn, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("mitch: %w", err)
+	}
+	stop := watchContext(ctx, conn)
+	defer stop()
+	return ctxIOError(ctx, MitchSendTCP(conn, data))
+}
+
+// MitchRecvMessageContext reads one complete MITCH message, honoring
+// context cancellation and deadline.
+func MitchRecvMessageContext(ctx context.Context, conn net.Conn) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("mitch: %w", err)
+	}
+	stop := watchContext(ctx, conn)
+	defer stop()
+	message, err := MitchRecvMessage(conn)
+	if err != nil {
+		return nil, ctxIOError(ctx, err)
+	}
+	return message, nil
+}
@@ -0,0 +1,77 @@
+package mitch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestMitchRecvMessageContextDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := MitchRecvMessageContext(ctx, client) // nothing is ever sent
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("recv took %v, should have returned promptly", elapsed)
+	}
+}
+
+func TestMitchRecvMessageContextCancelMidRead(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Send only a partial header, then cancel while the reader waits
+		// for the rest.
+		server.Write([]byte{byte(MsgTypeTrade), 0, 0})
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := MitchRecvMessageContext(ctx, client)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestMitchSendTCPContextRoundTrip(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1, TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- MitchSendTCPContext(ctx, server, message)
+	}()
+
+	got, err := MitchRecvMessageContext(ctx, client)
+	if err != nil {
+		t.Fatalf("recv failed: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	if len(got) != len(message) {
+		t.Errorf("received %d bytes, want %d", len(got), len(message))
+	}
+}
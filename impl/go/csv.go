@@ -0,0 +1,127 @@
+// CSV export of decoded message batches.
+//
+// Analysts inspect captured streams in spreadsheets; these writers dump a
+// decoded batch as one CSV header row plus one line per body, with the
+// 48-bit timestamp expanded to a readable time and combined fields split
+// into named columns.
+
+package mitch
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteTradesCSV writes a header row and one line per trade. All fields
+// are numeric or fixed enum names, so no escaping concerns arise; the
+// writer is flushed before returning and the first write error is returned.
+func WriteTradesCSV(w io.Writer, header *MitchHeader, trades []*TradeBody) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "ticker_id", "price", "quantity", "trade_id", "side"}); err != nil {
+		return err
+	}
+	ts := jsonTime(header.Timestamp)
+	for _, t := range trades {
+		record := []string{
+			ts,
+			strconv.FormatUint(t.TickerID, 10),
+			strconv.FormatFloat(t.Price, 'g', -1, 64),
+			strconv.FormatUint(uint64(t.Quantity), 10),
+			strconv.FormatUint(uint64(t.TradeID), 10),
+			sideString(t.Side),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteOrdersCSV writes a header row and one line per order, splitting
+// TypeAndSide into separate order_type and side columns.
+func WriteOrdersCSV(w io.Writer, header *MitchHeader, orders []*OrderBody) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "ticker_id", "order_id", "price", "quantity", "order_type", "side", "expiry"}); err != nil {
+		return err
+	}
+	ts := jsonTime(header.Timestamp)
+	for _, o := range orders {
+		expiry := ""
+		if ReadTimestamp48(o.Expiry) != 0 {
+			expiry = jsonTime(o.Expiry)
+		}
+		record := []string{
+			ts,
+			strconv.FormatUint(o.TickerID, 10),
+			strconv.FormatUint(uint64(o.OrderID), 10),
+			strconv.FormatFloat(o.Price, 'g', -1, 64),
+			strconv.FormatUint(uint64(o.Quantity), 10),
+			orderTypeString(o.TypeAndSide >> 1),
+			sideString(o.TypeAndSide & 1),
+			expiry,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTickersCSV writes a header row and one line per ticker snapshot.
+func WriteTickersCSV(w io.Writer, header *MitchHeader, tickers []*TickerBody) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "ticker_id", "bid_price", "ask_price", "bid_volume", "ask_volume"}); err != nil {
+		return err
+	}
+	ts := jsonTime(header.Timestamp)
+	for _, t := range tickers {
+		record := []string{
+			ts,
+			strconv.FormatUint(t.TickerID, 10),
+			strconv.FormatFloat(t.BidPrice, 'g', -1, 64),
+			strconv.FormatFloat(t.AskPrice, 'g', -1, 64),
+			strconv.FormatUint(uint64(t.BidVolume), 10),
+			strconv.FormatUint(uint64(t.AskVolume), 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteOrderBookCSV writes a header row and one line per price level,
+// expanding the book into (price, volume) rows in storage order.
+func WriteOrderBookCSV(w io.Writer, header *MitchHeader, book *OrderBookBody, volumes []uint32) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"timestamp", "ticker_id", "side", "level", "price", "volume"}); err != nil {
+		return err
+	}
+	ts := jsonTime(header.Timestamp)
+	var err error
+	level := 0
+	book.Iterate(volumes, func(price float64, volume uint32) {
+		if err != nil {
+			return
+		}
+		record := []string{
+			ts,
+			strconv.FormatUint(book.TickerID, 10),
+			bookSideString(book.Side),
+			strconv.Itoa(level),
+			strconv.FormatFloat(price, 'g', -1, 64),
+			strconv.FormatUint(uint64(volume), 10),
+		}
+		err = cw.Write(record)
+		level++
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,92 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteTradesCSV(t *testing.T) {
+	header := &MitchHeader{MessageType: MsgTypeTrade, Timestamp: WriteTimestamp48(3600_000_000_000), Count: 2}
+	trades := []*TradeBody{
+		{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 7, Side: SideBuy},
+		{TickerID: 2, Price: 99.25, Quantity: 20, TradeID: 8, Side: SideSell},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTradesCSV(&buf, header, trades); err != nil {
+		t.Fatalf("WriteTradesCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	if lines[0] != "timestamp,ticker_id,price,quantity,trade_id,side" {
+		t.Errorf("header row = %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",1,100.5,10,7,buy") {
+		t.Errorf("trade row = %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",2,99.25,20,8,sell") {
+		t.Errorf("trade row = %q", lines[2])
+	}
+}
+
+func TestWriteOrdersCSVSplitsTypeAndSide(t *testing.T) {
+	header := &MitchHeader{MessageType: MsgTypeOrder, Count: 1}
+	order := &OrderBody{TickerID: 3, OrderID: 11, Price: 50, Quantity: 5,
+		TypeAndSide: OrderTypeLimit<<1 | SideSell}
+
+	var buf bytes.Buffer
+	if err := WriteOrdersCSV(&buf, header, []*OrderBody{order}); err != nil {
+		t.Fatalf("WriteOrdersCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if !strings.Contains(lines[1], ",limit,sell,") {
+		t.Errorf("order row should split type and side: %q", lines[1])
+	}
+}
+
+func TestWriteOrderBookCSVLevels(t *testing.T) {
+	header := &MitchHeader{MessageType: MsgTypeOrderBook, Count: 1}
+	book := &OrderBookBody{TickerID: 9, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: SideSell}
+
+	var buf bytes.Buffer
+	if err := WriteOrderBookCSV(&buf, header, book, []uint32{10, 20, 30}); err != nil {
+		t.Fatalf("WriteOrderBookCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4", len(lines))
+	}
+	if !strings.HasSuffix(lines[2], ",asks,1,100.5,20") {
+		t.Errorf("level row = %q", lines[2])
+	}
+}
+
+// failWriter errors after n successful writes.
+type failWriter struct {
+	n   int
+	err error
+}
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	if f.n <= 0 {
+		return 0, f.err
+	}
+	f.n--
+	return len(p), nil
+}
+
+func TestWriteTradesCSVPropagatesWriteError(t *testing.T) {
+	wantErr := errors.New("disk full")
+	header := &MitchHeader{MessageType: MsgTypeTrade, Count: 1}
+	err := WriteTradesCSV(&failWriter{n: 0, err: wantErr}, header, []*TradeBody{{TradeID: 1}})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
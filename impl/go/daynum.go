@@ -0,0 +1,42 @@
+// Self-dating messages via a day-number header extension.
+//
+// The 48-bit timestamp restarts at midnight UTC, so a consumer replaying
+// a capture that spans midnight has to guess which day each message
+// belongs to. HeaderV3 removes the guesswork by appending a 16-bit
+// day-number — days since the Unix epoch, good through the year 2149 —
+// after the v2 sequence, making every message self-dating.
+
+package mitch
+
+import "time"
+
+// HeaderV3 appends a big-endian uint16 day-number (days since the Unix
+// epoch, UTC) to the v2 layout, so the 48-bit intraday timestamp resolves
+// to an absolute time without external context.
+const HeaderV3 HeaderVersion = 3
+
+// HeaderSizeV3 is the v3 header wire size in bytes.
+const HeaderSizeV3 = HeaderSizeV2 + 2
+
+// DayNumber returns the days elapsed between the Unix epoch and t's UTC
+// date.
+func DayNumber(t time.Time) uint16 {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return uint16(midnight.Unix() / (24 * 60 * 60))
+}
+
+// DayTime returns the UTC midnight opening the given day-number's day.
+func DayTime(day uint16) time.Time {
+	return time.Unix(int64(day)*24*60*60, 0).UTC()
+}
+
+// Time resolves the header's 48-bit intraday timestamp to an absolute
+// time using its day-number. For pre-v3 headers, which carry no date, it
+// returns the zero time.
+func (h *VersionedHeader) Time() time.Time {
+	if h.Version < HeaderV3 {
+		return time.Time{}
+	}
+	return TimestampToTime(h.Timestamp, DayTime(h.Day))
+}
@@ -0,0 +1,71 @@
+package mitch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayNumberRoundTrip(t *testing.T) {
+	dates := []time.Time{
+		time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.August, 31, 15, 30, 0, 0, time.UTC),
+		time.Date(2149, time.June, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, date := range dates {
+		day := DayNumber(date)
+		midnight := DayTime(day)
+		if midnight.Year() != date.Year() || midnight.YearDay() != date.YearDay() {
+			t.Errorf("DayTime(DayNumber(%v)) = %v", date, midnight)
+		}
+		if midnight.Hour() != 0 || midnight.Minute() != 0 {
+			t.Errorf("DayTime(%d) = %v, want a midnight", day, midnight)
+		}
+	}
+
+	// Consecutive days differ by exactly one.
+	a := DayNumber(time.Date(2025, time.March, 9, 23, 59, 0, 0, time.UTC))
+	b := DayNumber(time.Date(2025, time.March, 10, 0, 1, 0, 0, time.UTC))
+	if b != a+1 {
+		t.Errorf("day numbers across midnight = %d, %d", a, b)
+	}
+}
+
+func TestHeaderV3SelfDating(t *testing.T) {
+	moment := time.Date(2025, time.August, 30, 23, 59, 58, 0, time.UTC)
+	header := VersionedHeader{
+		MitchHeader: MitchHeader{
+			MessageType: MsgTypeTrade,
+			Timestamp:   WriteTimestamp48(nanosSinceMidnight(moment)),
+			Count:       1,
+		},
+		Version:  HeaderV3,
+		Sequence: 7,
+		Day:      DayNumber(moment),
+	}
+
+	packed, err := PackHeaderVersioned(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packed) != HeaderSizeV3 {
+		t.Fatalf("packed %d bytes, want %d", len(packed), HeaderSizeV3)
+	}
+
+	parsed, _, err := UnpackHeaderVersioned(HeaderV3, packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Sequence != 7 || parsed.Day != DayNumber(moment) {
+		t.Errorf("parsed = %+v", parsed)
+	}
+	// A batch spanning midnight stays unambiguous: the absolute time
+	// comes straight off the wire.
+	if got := parsed.Time(); !got.Equal(moment) {
+		t.Errorf("Time() = %v, want %v", got, moment)
+	}
+
+	v1 := VersionedHeader{MitchHeader: header.MitchHeader, Version: HeaderV1}
+	if !v1.Time().IsZero() {
+		t.Error("pre-v3 headers carry no date and must report the zero time")
+	}
+}
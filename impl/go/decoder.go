@@ -0,0 +1,358 @@
+// Streaming decoder for framed MITCH messages.
+//
+// MitchRecvMessage assumes fixed 32-byte bodies and cannot frame order
+// books; the Decoder inspects the message type and reads exactly the right
+// number of bytes per message, including variable order-book sizing, so
+// callers can loop over a TCP stream.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+)
+
+// OrderBookEntry pairs a decoded order book header with its volume array.
+// The Decoder and UnpackAny return these for 'b' messages so the volumes
+// stay attached to their book.
+type OrderBookEntry struct {
+	Body    *OrderBookBody
+	Volumes []uint32
+}
+
+// DefaultMaxBodyBytes is the body-byte budget a Decoder applies per message
+// unless MaxBodyBytes overrides it. Every legal message fits well under
+// 1 MiB (a full frame of 255 fixed bodies is 8160 bytes, the largest book
+// ~262 KB), so the default only trips on corrupt or hostile headers;
+// untrusting callers can tighten it further.
+const DefaultMaxBodyBytes = 1 << 20
+
+// Decoder reads framed MITCH messages from an io.Reader. It maintains an
+// internal scratch buffer so a caller can loop Decode over a stream and get
+// one fully-parsed message per call. It is not safe for concurrent use.
+type Decoder struct {
+	r            io.Reader
+	buf          []byte
+	validate     bool
+	skipUnknown  bool
+	maxBodyBytes int
+	stats        *Stats
+	instruments  *InstrumentRegistry
+	misaligned   uint64
+	log          Logger
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Validate controls whether Decode runs each body's Validate method after
+// unmarshaling, surfacing failures as *ValidationError. Off by default.
+func (d *Decoder) Validate(enable bool) {
+	d.validate = enable
+}
+
+// CollectStats makes Decode record each successfully decoded message in
+// stats. Pass nil to stop collecting.
+func (d *Decoder) CollectStats(stats *Stats) {
+	d.stats = stats
+}
+
+// SetLogger routes decode diagnostics — failed and skipped messages — to
+// l. The default is a no-op logger.
+func (d *Decoder) SetLogger(l Logger) {
+	d.log = orNop(l)
+}
+
+// logger returns the configured logger or the no-op default.
+func (d *Decoder) logger() Logger {
+	return orNop(d.log)
+}
+
+// SkipUnknown makes Decode discard messages of unknown type and continue
+// with the next message instead of erroring, for forward compatibility
+// with additive protocol changes. Unknown types are assumed to carry the
+// standard fixed 32-byte bodies; an unknown variable-length type cannot be
+// skipped because nothing tells the decoder its size, so a publisher
+// adding one breaks older consumers regardless. Off by default.
+func (d *Decoder) SkipUnknown(enable bool) {
+	d.skipUnknown = enable
+}
+
+// MaxBodyBytes caps the body bytes a single Decode will read, so a corrupt
+// or hostile header cannot force a large allocation: fixed-size messages
+// are capped at Count times the body size and each order book at
+// NumTicks*4 volume bytes. The default is DefaultMaxBodyBytes; trusted
+// internal feeds can raise it, and n <= 0 removes the limit entirely.
+func (d *Decoder) MaxBodyBytes(n int) {
+	if n <= 0 {
+		n = int(^uint(0) >> 1)
+	}
+	d.maxBodyBytes = n
+}
+
+// Normalize makes Decode snap decoded prices onto each instrument's tick
+// grid using the registry's metadata; prices that were already aligned
+// pass through bit-identical. Off-grid prices are counted in Misaligned.
+// Pass nil to disable.
+func (d *Decoder) Normalize(instruments *InstrumentRegistry) {
+	d.instruments = instruments
+}
+
+// Misaligned returns how many decoded prices were off their instrument's
+// tick grid before normalization snapped them.
+func (d *Decoder) Misaligned() uint64 {
+	return d.misaligned
+}
+
+// limit returns the effective per-message body-byte budget.
+func (d *Decoder) limit() int {
+	if d.maxBodyBytes == 0 {
+		return DefaultMaxBodyBytes
+	}
+	return d.maxBodyBytes
+}
+
+// Decode reads and parses the next message from the stream. The returned
+// slice holds *TradeBody, *OrderBody, *TickerBody or *OrderBookEntry values
+// depending on the message type. At a clean end of stream it returns io.EOF;
+// a stream ending mid-message returns io.ErrUnexpectedEOF.
+func (d *Decoder) Decode() (*MitchHeader, []interface{}, error) {
+	header, bodies, err := d.decode()
+	if err != nil && err != io.EOF {
+		d.logger().Errorf("mitch: decode: %v", err)
+	}
+	return header, bodies, err
+}
+
+// decode is Decode without the error logging.
+func (d *Decoder) decode() (*MitchHeader, []interface{}, error) {
+	// io.ReadFull returns io.EOF only when zero bytes were read, which is
+	// the clean end-of-stream case; a partial header surfaces as
+	// io.ErrUnexpectedEOF.
+	headerBytes, err := d.readFull(HeaderSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := unpackHeader(headerBytes)
+
+	for d.skipUnknown {
+		if _, known := BodySize(header.MessageType); known {
+			break
+		}
+		d.logger().Debugf("mitch: skipping unknown message type 0x%02X (%d bodies)", byte(header.MessageType), header.Count)
+		// Assume the standard fixed body size and discard the message.
+		if _, err := d.readFull(int(header.Count) * BodySizeFixed); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, nil, err
+		}
+		headerBytes, err = d.readFull(HeaderSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		header = unpackHeader(headerBytes)
+	}
+
+	if size, _ := BodySize(header.MessageType); size > 0 {
+		if total := int(header.Count) * size; total > d.limit() {
+			return nil, nil, fmt.Errorf("mitch: %w: %d body bytes exceed limit %d", ErrMessageTooLarge, total, d.limit())
+		}
+	}
+
+	bodies := make([]interface{}, 0, header.Count)
+	for i := 0; i < int(header.Count); i++ {
+		switch header.MessageType {
+		case MsgTypeTrade:
+			raw, err := d.readBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			body := new(TradeBody)
+			if err := body.UnmarshalBinary(raw); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+		case MsgTypeOrder:
+			raw, err := d.readBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			body := new(OrderBody)
+			if err := body.UnmarshalBinary(raw); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+		case MsgTypeTicker:
+			raw, err := d.readBody()
+			if err != nil {
+				return nil, nil, err
+			}
+			body, err := DecodeTicker(raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, &body)
+		case MsgTypeOrderBook:
+			entry, err := d.readOrderBook()
+			if err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, entry)
+		case MsgTypeHeartbeat:
+			raw, err := d.readFull(HeartbeatBodySize)
+			if err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, nil, err
+			}
+			body := new(HeartbeatBody)
+			if err := body.UnmarshalBinary(raw); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+		case MsgTypeBookChecksum:
+			raw, err := d.readFull(BookChecksumBodySize)
+			if err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, nil, err
+			}
+			body := new(BookChecksumBody)
+			if err := body.UnmarshalBinary(raw); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+		default:
+			return nil, nil, fmt.Errorf("mitch: %w 0x%02X", ErrUnknownMessageType, header.MessageType)
+		}
+		if d.validate {
+			if v, ok := bodies[len(bodies)-1].(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		if d.instruments != nil {
+			if d.instruments.normalizeBody(bodies[len(bodies)-1]) {
+				d.misaligned++
+			}
+		}
+	}
+	if d.stats != nil {
+		d.stats.Record(header.MessageType, len(bodies))
+	}
+	return header, bodies, nil
+}
+
+// Skip reads the next message off the stream and discards it, returning
+// only the message type. It frames the message exactly like Decode —
+// including per-body order-book sizing — but never unmarshals, validates
+// or allocates bodies, so it is the cheap path when the consumer will
+// drop the message anyway. Skipped messages are not recorded in stats.
+func (d *Decoder) Skip() (byte, error) {
+	headerBytes, err := d.readFull(HeaderSize)
+	if err != nil {
+		return 0, err
+	}
+	header := unpackHeader(headerBytes)
+
+	size, known := BodySize(header.MessageType)
+	if !known {
+		return 0, fmt.Errorf("mitch: %w 0x%02X", ErrUnknownMessageType, header.MessageType)
+	}
+
+	if size == BodySizeVariable {
+		// Each book declares its own length: read the 32-byte header to
+		// learn NumTicks, then discard that many volume words.
+		for i := 0; i < int(header.Count); i++ {
+			raw, err := d.readBody()
+			if err != nil {
+				return 0, err
+			}
+			numTicks := ByteOrder.Uint16(raw[24:26])
+			if err := checkBookLevels(numTicks); err != nil {
+				return 0, err
+			}
+			if volumeBytes := int(numTicks) * 4; volumeBytes > d.limit() {
+				return 0, fmt.Errorf("mitch: %w: order book claims %d volume bytes, limit %d", ErrMessageTooLarge, volumeBytes, d.limit())
+			}
+			if _, err := d.readFull(int(numTicks) * 4); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+				return 0, err
+			}
+		}
+		return byte(header.MessageType), nil
+	}
+
+	total := int(header.Count) * size
+	if total > d.limit() {
+		return 0, fmt.Errorf("mitch: %w: %d body bytes exceed limit %d", ErrMessageTooLarge, total, d.limit())
+	}
+	if _, err := d.readFull(total); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return byte(header.MessageType), nil
+}
+
+// readOrderBook reads one variable-length book: the 32-byte book header
+// then NumTicks uint32 volumes.
+func (d *Decoder) readOrderBook() (*OrderBookEntry, error) {
+	raw, err := d.readBody()
+	if err != nil {
+		return nil, err
+	}
+	book := unpackOrderBookBody(raw)
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return nil, err
+	}
+	if volumeBytes := int(book.NumTicks) * 4; volumeBytes > d.limit() {
+		return nil, fmt.Errorf("mitch: %w: order book claims %d volume bytes, limit %d", ErrMessageTooLarge, volumeBytes, d.limit())
+	}
+
+	raw, err = d.readFull(int(book.NumTicks) * 4)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	volumes := make([]uint32, book.NumTicks)
+	for i := range volumes {
+		volumes[i] = ByteOrder.Uint32(raw[i*4 : i*4+4])
+	}
+	return &OrderBookEntry{Body: book, Volumes: volumes}, nil
+}
+
+// readBody reads one fixed 32-byte body into the scratch buffer. A stream
+// ending at a body boundary is still a truncated message, so io.EOF is
+// promoted to io.ErrUnexpectedEOF here.
+func (d *Decoder) readBody() ([]byte, error) {
+	raw, err := d.readFull(BodySizeFixed)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return raw, err
+}
+
+// readFull reads exactly n bytes into the reused scratch buffer. The
+// returned slice is only valid until the next read.
+func (d *Decoder) readFull(n int) ([]byte, error) {
+	if cap(d.buf) < n {
+		d.buf = make([]byte, n)
+	}
+	buf := d.buf[:n]
+	if _, err := io.ReadFull(d.r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,155 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecoderStream(t *testing.T) {
+	var stream bytes.Buffer
+
+	trades, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 10, Quantity: 100, TradeID: 1, Side: SideBuy},
+		TradeBody{TickerID: 2, Price: 20, Quantity: 200, TradeID: 2, Side: SideSell},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(trades)
+
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 3, FirstTick: 99, TickSize: 0.5, NumTicks: 6, Side: SideSell},
+		[]uint32{1, 2, 3, 4, 5, 6},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(book)
+
+	ticker, err := PackMessage(MsgTypeTicker,
+		TickerBody{TickerID: 4, BidPrice: 1.1, AskPrice: 1.2, BidVolume: 10, AskVolume: 20},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(ticker)
+
+	d := NewDecoder(&stream)
+
+	header, bodies, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode trades failed: %v", err)
+	}
+	if header.MessageType != MsgTypeTrade || len(bodies) != 2 {
+		t.Fatalf("trades: type=%q bodies=%d", header.MessageType, len(bodies))
+	}
+	if trade := bodies[1].(*TradeBody); trade.TradeID != 2 {
+		t.Errorf("trade 1 = %+v", trade)
+	}
+
+	header, bodies, err = d.Decode()
+	if err != nil {
+		t.Fatalf("Decode order book failed: %v", err)
+	}
+	if header.MessageType != MsgTypeOrderBook || len(bodies) != 1 {
+		t.Fatalf("book: type=%q bodies=%d", header.MessageType, len(bodies))
+	}
+	entry := bodies[0].(*OrderBookEntry)
+	if entry.Body.NumTicks != 6 || len(entry.Volumes) != 6 || entry.Volumes[5] != 6 {
+		t.Errorf("book entry = %+v", entry)
+	}
+
+	header, bodies, err = d.Decode()
+	if err != nil {
+		t.Fatalf("Decode ticker failed: %v", err)
+	}
+	if header.MessageType != MsgTypeTicker || len(bodies) != 1 {
+		t.Fatalf("ticker: type=%q bodies=%d", header.MessageType, len(bodies))
+	}
+
+	if _, _, err := d.Decode(); err != io.EOF {
+		t.Errorf("at end of stream err = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderTruncatedMessage(t *testing.T) {
+	data, err := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDecoder(bytes.NewReader(data[:len(data)-5]))
+	if _, _, err := d.Decode(); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated message err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestDecoderSkip(t *testing.T) {
+	var stream bytes.Buffer
+
+	trades, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, TradeID: 1},
+		TradeBody{TickerID: 2, TradeID: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(trades)
+
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 3, FirstTick: 99, TickSize: 0.5, NumTicks: 4, Side: BookSideAsk},
+		[]uint32{1, 2, 3, 4},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(book)
+
+	ticker, err := PackMessage(MsgTypeTicker,
+		TickerBody{TickerID: 4, BidPrice: 1.1, AskPrice: 1.2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(ticker)
+
+	d := NewDecoder(&stream)
+
+	// Skip frames both the fixed trade message and the variable book,
+	// leaving the decoder aligned on the ticker.
+	if got, err := d.Skip(); err != nil || got != byte(MsgTypeTrade) {
+		t.Fatalf("Skip = (%q, %v), want trade", got, err)
+	}
+	if got, err := d.Skip(); err != nil || got != byte(MsgTypeOrderBook) {
+		t.Fatalf("Skip = (%q, %v), want order book", got, err)
+	}
+	header, bodies, err := d.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.MessageType != MsgTypeTicker || bodies[0].(*TickerBody).TickerID != 4 {
+		t.Errorf("after skips decoded %v, want the ticker", header.MessageType)
+	}
+	if _, err := d.Skip(); err != io.EOF {
+		t.Errorf("Skip at end of stream = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderSkipTruncated(t *testing.T) {
+	trades, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDecoder(bytes.NewReader(trades[:len(trades)-4]))
+	if _, err := d.Skip(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Skip on truncated message = %v, want io.ErrUnexpectedEOF", err)
+	}
+
+	// An unknown type cannot be framed, so Skip errors rather than guessing.
+	unknown := append([]byte{'z'}, trades[1:HeaderSize]...)
+	d = NewDecoder(bytes.NewReader(unknown))
+	if _, err := d.Skip(); !errors.Is(err, ErrUnknownMessageType) {
+		t.Errorf("Skip on unknown type = %v, want ErrUnknownMessageType", err)
+	}
+}
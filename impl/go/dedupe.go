@@ -0,0 +1,66 @@
+// Duplicate ID suppression over a bounded window.
+//
+// Flaky upstreams resend trades, and TradeIDs are only uint32 so they
+// eventually wrap: remembering every ID forever would both leak memory
+// and start misfiring after a wrap. Deduper remembers the last N IDs in a
+// ring; a duplicate arriving within the window is caught, one arriving
+// after eviction is not. Sizing the window is the caller's
+// memory-versus-false-negative tradeoff — roughly 12 bytes per remembered
+// ID, and the window only needs to cover the upstream's plausible resend
+// horizon.
+
+package mitch
+
+import "sync"
+
+// Deduper tracks recently seen uint32 IDs in a fixed-size window. Safe
+// for concurrent use.
+type Deduper struct {
+	mu   sync.Mutex
+	seen map[uint32]struct{}
+	ring []uint32
+	pos  int
+	full bool
+}
+
+// NewDeduper returns a Deduper remembering the most recent window IDs.
+// A window below 1 is treated as 1.
+func NewDeduper(window int) *Deduper {
+	if window < 1 {
+		window = 1
+	}
+	return &Deduper{
+		seen: make(map[uint32]struct{}, window),
+		ring: make([]uint32, window),
+	}
+}
+
+// Seen reports whether id was already observed within the window and
+// records it. The oldest remembered ID is evicted once the window is
+// full, so the second copy of an ID more than window observations apart
+// is not detected.
+func (d *Deduper) Seen(id uint32) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.seen[id]; ok {
+		return true
+	}
+	if d.full {
+		delete(d.seen, d.ring[d.pos])
+	}
+	d.seen[id] = struct{}{}
+	d.ring[d.pos] = id
+	d.pos++
+	if d.pos == len(d.ring) {
+		d.pos = 0
+		d.full = true
+	}
+	return false
+}
+
+// Len returns how many IDs the window currently remembers.
+func (d *Deduper) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.seen)
+}
@@ -0,0 +1,49 @@
+package mitch
+
+import "testing"
+
+func TestDeduperCatchesDuplicatesInWindow(t *testing.T) {
+	d := NewDeduper(4)
+	if d.Seen(1) {
+		t.Error("first observation reported as seen")
+	}
+	if !d.Seen(1) {
+		t.Error("duplicate within window not caught")
+	}
+	if d.Seen(2) || d.Seen(3) {
+		t.Error("fresh IDs reported as seen")
+	}
+	if !d.Seen(2) {
+		t.Error("duplicate of 2 not caught")
+	}
+}
+
+func TestDeduperEvictsOldest(t *testing.T) {
+	d := NewDeduper(3)
+	d.Seen(1)
+	d.Seen(2)
+	d.Seen(3)
+	// Window is full; observing 4 evicts 1.
+	d.Seen(4)
+	if d.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", d.Len())
+	}
+	if d.Seen(1) {
+		t.Error("evicted ID must read as unseen (the documented false negative)")
+	}
+	if !d.Seen(4) {
+		t.Error("recent ID lost after eviction")
+	}
+}
+
+func TestDeduperMinimumWindow(t *testing.T) {
+	d := NewDeduper(0)
+	d.Seen(7)
+	if !d.Seen(7) {
+		t.Error("window of 1 must still catch an immediate duplicate")
+	}
+	d.Seen(8)
+	if d.Len() != 1 {
+		t.Errorf("Len = %d, want 1", d.Len())
+	}
+}
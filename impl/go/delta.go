@@ -0,0 +1,143 @@
+// Partial order book updates carrying only the changed levels.
+//
+// An active instrument's book changes a few levels at a time; resending
+// the whole dense array wastes most of the bandwidth on levels that did
+// not move. A delta reuses the (uint16 index, uint32 volume) pair layout
+// of the sparse encoding but with different semantics: a sparse book is a
+// complete picture whose omitted levels are zero, while a delta touches
+// only the listed indexes and leaves everything else alone. A zero volume
+// in a delta removes the level. Padding[1] carries a distinct flag so
+// receivers cannot confuse the two.
+
+package mitch
+
+import (
+	"fmt"
+	"time"
+)
+
+// deltaVolumeFlag in Padding[1] marks a book whose pairs are a partial
+// update rather than a complete sparse picture.
+const deltaVolumeFlag = 2
+
+// DeltaLevel is one changed level of a partial book update: the level's
+// position in the dense grid and its new volume. Volume zero removes the
+// level.
+type DeltaLevel struct {
+	Index  uint16
+	Volume uint32
+}
+
+// OrderBookDelta is a partial update to one side of a book: the grid
+// description (ticker, side, FirstTick, TickSize, NumTicks) plus only the
+// levels that changed.
+type OrderBookDelta struct {
+	Book    OrderBookBody
+	Changes []DeltaLevel
+}
+
+// IsDelta reports whether the book's pairs are delta-encoded.
+func (b *OrderBookBody) IsDelta() bool {
+	return b.Padding[1] == deltaVolumeFlag
+}
+
+// PackOrderBookDelta serializes a partial book update. Every change index
+// must fall inside the grid described by delta.Book.NumTicks.
+func PackOrderBookDelta(header MitchHeader, delta OrderBookDelta) ([]byte, error) {
+	book := delta.Book
+	if book.NumTicks == 0 {
+		return nil, fmt.Errorf("mitch: order book has zero ticks")
+	}
+	for i, change := range delta.Changes {
+		if int(change.Index) >= int(book.NumTicks) {
+			return nil, fmt.Errorf("mitch: delta change %d index %d out of range (book has %d ticks)", i, change.Index, book.NumTicks)
+		}
+	}
+	book.Padding[1] = deltaVolumeFlag
+	ByteOrder.PutUint16(book.Padding[2:4], uint16(len(delta.Changes)))
+
+	header.MessageType = MsgTypeOrderBook
+	header.Count = 1
+	if ReadTimestamp48(header.Timestamp) == 0 {
+		header.Timestamp = WriteTimestamp48(nanosSinceMidnight(time.Now()))
+	}
+
+	data := make([]byte, HeaderSize+BodySizeFixed+len(delta.Changes)*sparsePairSize)
+	packHeaderInto(data, header)
+	packOrderBookBodyInto(data[HeaderSize:], book)
+	off := HeaderSize + BodySizeFixed
+	for _, change := range delta.Changes {
+		ByteOrder.PutUint16(data[off:off+2], change.Index)
+		ByteOrder.PutUint32(data[off+2:off+6], change.Volume)
+		off += sparsePairSize
+	}
+	return data, nil
+}
+
+// UnpackOrderBookDelta deserializes a partial book update. Change indexes
+// are validated against the grid; the returned book has its delta flag
+// cleared.
+func UnpackOrderBookDelta(data []byte) (*MitchHeader, *OrderBookDelta, error) {
+	if len(data) < HeaderSize+BodySizeFixed {
+		return nil, nil, fmt.Errorf("mitch: %w: order book message is %d bytes (need at least %d)", ErrShortBuffer, len(data), HeaderSize+BodySizeFixed)
+	}
+
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeOrderBook {
+		return nil, nil, fmt.Errorf("mitch: message type %q is not an order book", header.MessageType)
+	}
+	book := unpackOrderBookBody(data[HeaderSize:])
+	if !book.IsDelta() {
+		return nil, nil, fmt.Errorf("mitch: order book is not delta-encoded; use UnpackOrderBook")
+	}
+
+	if err := book.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return nil, nil, err
+	}
+	pairs := book.sparsePairCount()
+	expected := HeaderSize + BodySizeFixed + pairs*sparsePairSize
+	if len(data) < expected {
+		return nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d changes, got %d", ErrShortBuffer, expected, pairs, len(data))
+	}
+
+	changes := make([]DeltaLevel, pairs)
+	off := HeaderSize + BodySizeFixed
+	for i := 0; i < pairs; i++ {
+		index := ByteOrder.Uint16(data[off : off+2])
+		if int(index) >= int(book.NumTicks) {
+			return nil, nil, fmt.Errorf("mitch: delta change %d index %d out of range (book has %d ticks)", i, index, book.NumTicks)
+		}
+		changes[i] = DeltaLevel{Index: index, Volume: ByteOrder.Uint32(data[off+2 : off+6])}
+		off += sparsePairSize
+	}
+
+	book.Padding[1] = 0
+	book.Padding[2], book.Padding[3] = 0, 0
+	return header, &OrderBookDelta{Book: *book, Changes: changes}, nil
+}
+
+// ApplyDelta merges a partial update into the book. Only the listed
+// levels change: each is set to its new volume, with zero removing the
+// level; every other price is left untouched.
+func (b *Book) ApplyDelta(delta *OrderBookDelta) {
+	side := b.asks
+	if delta.Book.Side == SideBuy {
+		side = b.bids
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	saved := b.saveSideLocked(side)
+	for _, change := range delta.Changes {
+		price := delta.Book.LevelPrice(int(change.Index))
+		if change.Volume == 0 {
+			delete(side, price)
+		} else {
+			side[price] = change.Volume
+		}
+	}
+	b.checkCrossLocked(delta.Book.Side, saved)
+}
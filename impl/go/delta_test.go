@@ -0,0 +1,117 @@
+package mitch
+
+import (
+	"strings"
+	"testing"
+)
+
+func deltaTestBook(side Side, numTicks uint16) OrderBookBody {
+	return OrderBookBody{
+		TickerID:  314,
+		FirstTick: 100.0,
+		TickSize:  0.5,
+		NumTicks:  numTicks,
+		Side:      side,
+	}
+}
+
+func TestOrderBookDeltaRoundTrip(t *testing.T) {
+	delta := OrderBookDelta{
+		Book: deltaTestBook(SideSell, 10),
+		Changes: []DeltaLevel{
+			{Index: 0, Volume: 500},
+			{Index: 3, Volume: 0},
+			{Index: 9, Volume: 42},
+		},
+	}
+	data, err := PackOrderBookDelta(MitchHeader{}, delta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := HeaderSize + BodySizeFixed + 3*sparsePairSize; len(data) != want {
+		t.Fatalf("packed %d bytes, want %d", len(data), want)
+	}
+
+	_, got, err := UnpackOrderBookDelta(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Book.TickerID != 314 || got.Book.NumTicks != 10 {
+		t.Errorf("book = %+v", got.Book)
+	}
+	if got.Book.IsDelta() {
+		t.Error("unpacked book should have its delta flag cleared")
+	}
+	if len(got.Changes) != 3 || got.Changes[0] != delta.Changes[0] || got.Changes[2] != delta.Changes[2] {
+		t.Errorf("changes = %+v", got.Changes)
+	}
+}
+
+func TestOrderBookDeltaIndexBounds(t *testing.T) {
+	bad := OrderBookDelta{
+		Book:    deltaTestBook(SideSell, 5),
+		Changes: []DeltaLevel{{Index: 5, Volume: 1}},
+	}
+	if _, err := PackOrderBookDelta(MitchHeader{}, bad); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("Pack with out-of-range index = %v, want out-of-range error", err)
+	}
+
+	// Corrupt a valid message so a change index points past the grid.
+	good := OrderBookDelta{
+		Book:    deltaTestBook(SideSell, 5),
+		Changes: []DeltaLevel{{Index: 2, Volume: 1}},
+	}
+	data, err := PackOrderBookDelta(MitchHeader{}, good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ByteOrder.PutUint16(data[HeaderSize+BodySizeFixed:], 9)
+	if _, _, err := UnpackOrderBookDelta(data); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("Unpack with corrupted index = %v, want out-of-range error", err)
+	}
+}
+
+func TestOrderBookDeltaRejectsOtherEncodings(t *testing.T) {
+	book := deltaTestBook(SideSell, 4)
+	data, err := PackOrderBook(MitchHeader{}, book, []uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UnpackOrderBookDelta(data); err == nil {
+		t.Error("UnpackOrderBookDelta accepted a dense book")
+	}
+
+	sparse, err := PackOrderBookSparse(MitchHeader{}, book, []uint32{1, 0, 0, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UnpackOrderBookDelta(sparse); err == nil {
+		t.Error("UnpackOrderBookDelta accepted a sparse book")
+	}
+}
+
+func TestBookApplyDelta(t *testing.T) {
+	book := NewBook()
+	asks := deltaTestBook(SideSell, 4)
+	book.Apply(&asks, []uint32{10, 20, 30, 40})
+
+	book.ApplyDelta(&OrderBookDelta{
+		Book: asks,
+		Changes: []DeltaLevel{
+			{Index: 0, Volume: 15}, // updated
+			{Index: 2, Volume: 0},  // removed
+		},
+	})
+
+	_, snapshot := book.Snapshot()
+	if len(snapshot) != 3 {
+		t.Fatalf("ask levels = %d, want 3 after one removal", len(snapshot))
+	}
+	if snapshot[0].Price != 100.0 || snapshot[0].Volume != 15 {
+		t.Errorf("best ask = %+v, want 100.0 x 15", snapshot[0])
+	}
+	// Untouched levels keep their volume.
+	if snapshot[1].Volume != 20 || snapshot[2].Volume != 40 {
+		t.Errorf("untouched levels = %+v", snapshot[1:])
+	}
+}
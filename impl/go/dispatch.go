@@ -0,0 +1,56 @@
+// Handler-based message dispatch.
+//
+// Receiver loops otherwise type-switch on every decoded body; Dispatch
+// parses a message and routes each body to the matching Handler method, so
+// consumers implement an interface instead of repeating assertions.
+
+package mitch
+
+// Handler receives decoded bodies, one callback per body, each with the
+// message header for the timestamp. Implementations that only care about a
+// subset can embed NopHandler and override the methods they need.
+type Handler interface {
+	OnTrade(header *MitchHeader, body *TradeBody)
+	OnOrder(header *MitchHeader, body *OrderBody)
+	OnTicker(header *MitchHeader, body *TickerBody)
+	OnOrderBook(header *MitchHeader, body *OrderBookBody, volumes []uint32)
+	OnHeartbeat(header *MitchHeader, body *HeartbeatBody)
+}
+
+// NopHandler implements Handler with empty methods, for embedding.
+type NopHandler struct{}
+
+func (NopHandler) OnTrade(*MitchHeader, *TradeBody)                   {}
+func (NopHandler) OnOrder(*MitchHeader, *OrderBody)                   {}
+func (NopHandler) OnTicker(*MitchHeader, *TickerBody)                 {}
+func (NopHandler) OnOrderBook(*MitchHeader, *OrderBookBody, []uint32) {}
+func (NopHandler) OnHeartbeat(*MitchHeader, *HeartbeatBody)           {}
+
+// Dispatch parses a complete message and routes every body to the matching
+// Handler method. A parse error returns before any callback fires.
+func Dispatch(data []byte, h Handler) error {
+	header, bodies, err := UnpackAny(data)
+	if err != nil {
+		return err
+	}
+	routeBodies(header, bodies, h)
+	return nil
+}
+
+// routeBodies fans decoded bodies out to the matching Handler methods.
+func routeBodies(header *MitchHeader, bodies []interface{}, h Handler) {
+	for _, body := range bodies {
+		switch b := body.(type) {
+		case *TradeBody:
+			h.OnTrade(header, b)
+		case *OrderBody:
+			h.OnOrder(header, b)
+		case *TickerBody:
+			h.OnTicker(header, b)
+		case *OrderBookEntry:
+			h.OnOrderBook(header, b.Body, b.Volumes)
+		case *HeartbeatBody:
+			h.OnHeartbeat(header, b)
+		}
+	}
+}
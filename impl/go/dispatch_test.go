@@ -0,0 +1,64 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+type recordingHandler struct {
+	NopHandler
+	trades  []uint32
+	tickers []uint32
+	volumes []uint32
+}
+
+func (h *recordingHandler) OnTrade(_ *MitchHeader, body *TradeBody) {
+	h.trades = append(h.trades, body.TradeID)
+}
+
+func (h *recordingHandler) OnTicker(_ *MitchHeader, body *TickerBody) {
+	h.tickers = append(h.tickers, uint32(body.TickerID))
+}
+
+func (h *recordingHandler) OnOrderBook(_ *MitchHeader, _ *OrderBookBody, volumes []uint32) {
+	h.volumes = volumes
+}
+
+func TestDispatchRoutesBodies(t *testing.T) {
+	var h recordingHandler
+
+	trades, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 5}, TradeBody{TradeID: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Dispatch(trades, &h); err != nil {
+		t.Fatalf("Dispatch trades: %v", err)
+	}
+	if len(h.trades) != 2 || h.trades[0] != 5 || h.trades[1] != 6 {
+		t.Errorf("trades = %v, want [5 6]", h.trades)
+	}
+
+	book, err := PackOrderBookBatch([]OrderBookBody{{TickerID: 9, FirstTick: 100, TickSize: 0.5, NumTicks: 2}}, [][]uint32{{10, 20}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Dispatch(book, &h); err != nil {
+		t.Fatalf("Dispatch book: %v", err)
+	}
+	if len(h.volumes) != 2 || h.volumes[1] != 20 {
+		t.Errorf("volumes = %v, want [10 20]", h.volumes)
+	}
+	if len(h.tickers) != 0 {
+		t.Errorf("unexpected ticker callbacks: %v", h.tickers)
+	}
+}
+
+func TestDispatchParseError(t *testing.T) {
+	var h recordingHandler
+	if err := Dispatch([]byte{1, 2}, &h); !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("err = %v, want ErrShortBuffer", err)
+	}
+	if len(h.trades) != 0 {
+		t.Error("no callback may fire on a parse error")
+	}
+}
@@ -0,0 +1,203 @@
+// Streaming encoder for framed MITCH messages.
+//
+// Complements the Decoder: packs and writes one message per Encode call,
+// reusing an internal scratch buffer between calls to avoid per-message
+// allocation.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// encoderFlushThreshold is how many buffered bytes trigger an automatic
+// write-through during Encode.
+const encoderFlushThreshold = 4096
+
+// Encoder writes framed MITCH messages to an io.Writer. It reuses an
+// internal scratch buffer between calls and is not safe for concurrent use.
+//
+// Encode may buffer: small messages accumulate and are written in batches,
+// so a reader on the other end of a pipe will not see them until Flush (or
+// Close) is called. Always Flush at natural boundaries and Close when done.
+type Encoder struct {
+	w       io.Writer
+	buf     []byte
+	pending []byte
+	closed  bool
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode packs the bodies into a single message of the given type and
+// buffers it for writing, flushing automatically once enough bytes
+// accumulate. Fixed-size bodies are passed by value or pointer; order books
+// are passed either as *OrderBookEntry or as a *OrderBookBody immediately
+// followed by its []uint32 volume slice. Unknown body types return an error
+// before anything is buffered, so a failed Encode never emits a malformed
+// frame. Call Flush to make buffered messages visible to the reader.
+func (e *Encoder) Encode(messageType MessageType, bodies ...interface{}) error {
+	if e.closed {
+		return fmt.Errorf("mitch: encode on closed encoder")
+	}
+	if len(bodies) == 0 {
+		return fmt.Errorf("mitch: %w to encode", ErrNoBodies)
+	}
+
+	buf := e.buf[:0]
+	// Header is appended after the bodies are validated and counted; leave
+	// room for it now so the bodies can stream into the same buffer.
+	buf = append(buf, make([]byte, HeaderSize)...)
+
+	count := 0
+	switch messageType {
+	case MsgTypeTrade, MsgTypeOrder, MsgTypeTicker:
+		for i, body := range bodies {
+			var err error
+			buf, err = appendFixedBody(buf, messageType, body)
+			if err != nil {
+				e.buf = buf[:0]
+				return fmt.Errorf("mitch: encode body %d: %w", i, err)
+			}
+			count++
+		}
+	case MsgTypeOrderBook:
+		for i := 0; i < len(bodies); i++ {
+			switch v := bodies[i].(type) {
+			case *OrderBookEntry:
+				var err error
+				buf, err = appendBookWithVolumes(buf, *v.Body, v.Volumes)
+				if err != nil {
+					e.buf = buf[:0]
+					return fmt.Errorf("mitch: encode book %d: %w", count, err)
+				}
+			case *OrderBookBody:
+				if i+1 >= len(bodies) {
+					e.buf = buf[:0]
+					return fmt.Errorf("mitch: book %d is missing its volume slice", count)
+				}
+				volumes, ok := bodies[i+1].([]uint32)
+				if !ok {
+					e.buf = buf[:0]
+					return fmt.Errorf("mitch: book %d must be followed by a []uint32 volume slice, got %T", count, bodies[i+1])
+				}
+				i++
+				var err error
+				buf, err = appendBookWithVolumes(buf, *v, volumes)
+				if err != nil {
+					e.buf = buf[:0]
+					return fmt.Errorf("mitch: encode book %d: %w", count, err)
+				}
+			default:
+				e.buf = buf[:0]
+				return fmt.Errorf("mitch: unsupported order book body type %T", bodies[i])
+			}
+			count++
+		}
+	default:
+		return fmt.Errorf("mitch: %w 0x%02X", ErrUnknownMessageType, messageType)
+	}
+
+	if count > 255 {
+		e.buf = buf[:0]
+		return fmt.Errorf("mitch: %w: %d (max 255)", ErrTooManyBodies, count)
+	}
+
+	packHeaderInto(buf, MitchHeader{
+		MessageType: messageType,
+		Timestamp:   WriteTimestamp48(nanosSinceMidnight(time.Now())),
+		Count:       uint8(count),
+	})
+
+	e.pending = append(e.pending, buf...)
+	e.buf = buf[:0]
+	if len(e.pending) >= encoderFlushThreshold {
+		return e.Flush()
+	}
+	return nil
+}
+
+// Flush writes all buffered messages to the underlying writer. Complete
+// messages are never split across the flush boundary: the buffer only
+// ever holds whole frames, so a reader sees an intact stream.
+func (e *Encoder) Flush() error {
+	if e.closed {
+		return fmt.Errorf("mitch: flush on closed encoder")
+	}
+	if len(e.pending) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.pending)
+	e.pending = e.pending[:0]
+	if err != nil {
+		return fmt.Errorf("mitch: encode write: %w", err)
+	}
+	return nil
+}
+
+// Close flushes buffered messages and, if the underlying writer is an
+// io.Closer, closes it. Encode and Flush fail after Close.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	flushErr := e.Flush()
+	e.closed = true
+	if closer, ok := e.w.(io.Closer); ok {
+		if err := closer.Close(); err != nil && flushErr == nil {
+			return fmt.Errorf("mitch: encoder close: %w", err)
+		}
+	}
+	return flushErr
+}
+
+// appendFixedBody appends one 32-byte body, accepting value or pointer
+// forms and checking the body kind against the message type.
+func appendFixedBody(dst []byte, messageType MessageType, body interface{}) ([]byte, error) {
+	switch messageType {
+	case MsgTypeTrade:
+		switch v := body.(type) {
+		case TradeBody:
+			return AppendTrade(dst, v), nil
+		case *TradeBody:
+			return AppendTrade(dst, *v), nil
+		}
+	case MsgTypeOrder:
+		switch v := body.(type) {
+		case OrderBody:
+			return AppendOrder(dst, v), nil
+		case *OrderBody:
+			return AppendOrder(dst, *v), nil
+		}
+	case MsgTypeTicker:
+		switch v := body.(type) {
+		case TickerBody:
+			return AppendTicker(dst, v), nil
+		case *TickerBody:
+			return AppendTicker(dst, *v), nil
+		}
+	}
+	return dst, fmt.Errorf("body type %T does not match message type %q", body, messageType)
+}
+
+// appendBookWithVolumes appends a 32-byte book header and its volumes,
+// validating the slice length against NumTicks.
+func appendBookWithVolumes(dst []byte, book OrderBookBody, volumes []uint32) ([]byte, error) {
+	if len(volumes) != int(book.NumTicks) {
+		return dst, fmt.Errorf("volume count %d does not match NumTicks %d", len(volumes), book.NumTicks)
+	}
+	var b []byte
+	dst, b = grow(dst, BodySizeFixed+len(volumes)*4)
+	packOrderBookBodyInto(b, book)
+	off := BodySizeFixed
+	for _, v := range volumes {
+		ByteOrder.PutUint32(b[off:off+4], v)
+		off += 4
+	}
+	return dst, nil
+}
@@ -0,0 +1,159 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncoderDecoderRoundTrip(t *testing.T) {
+	var stream bytes.Buffer
+	e := NewEncoder(&stream)
+
+	if err := e.Encode(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 10, Quantity: 100, TradeID: 1, Side: SideBuy},
+		&TradeBody{TickerID: 2, Price: 20, Quantity: 200, TradeID: 2, Side: SideSell},
+	); err != nil {
+		t.Fatalf("Encode trades failed: %v", err)
+	}
+
+	book := &OrderBookBody{TickerID: 3, FirstTick: 50, TickSize: 1, NumTicks: 4, Side: SideBuy}
+	if err := e.Encode(MsgTypeOrderBook, book, []uint32{5, 6, 7, 8}); err != nil {
+		t.Fatalf("Encode order book failed: %v", err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	d := NewDecoder(&stream)
+	header, bodies, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode trades failed: %v", err)
+	}
+	if header.Count != 2 || bodies[0].(*TradeBody).TradeID != 1 {
+		t.Errorf("trades: header=%+v bodies[0]=%+v", header, bodies[0])
+	}
+
+	header, bodies, err = d.Decode()
+	if err != nil {
+		t.Fatalf("Decode order book failed: %v", err)
+	}
+	entry := bodies[0].(*OrderBookEntry)
+	if header.MessageType != MsgTypeOrderBook || entry.Volumes[3] != 8 {
+		t.Errorf("book: header=%+v entry=%+v", header, entry)
+	}
+}
+
+func TestEncoderRejectsBadInput(t *testing.T) {
+	var stream bytes.Buffer
+	e := NewEncoder(&stream)
+
+	if err := e.Encode(MsgTypeTrade, OrderBody{}); err == nil {
+		t.Error("expected error for mismatched body type")
+	}
+	if err := e.Encode(MsgTypeOrderBook, &OrderBookBody{NumTicks: 2}); err == nil {
+		t.Error("expected error for book without volumes")
+	}
+	if err := e.Encode(MsgTypeOrderBook, &OrderBookBody{NumTicks: 2}, []uint32{1}); err == nil {
+		t.Error("expected error for volume length mismatch")
+	}
+	if err := e.Encode(0xFF, TradeBody{}); err == nil {
+		t.Error("expected error for unknown message type")
+	}
+	if stream.Len() != 0 {
+		t.Errorf("failed encodes wrote %d bytes, want 0", stream.Len())
+	}
+}
+
+func TestEncoderFlushBoundary(t *testing.T) {
+	var stream bytes.Buffer
+	e := NewEncoder(&stream)
+
+	if err := e.Encode(MsgTypeTrade, TradeBody{TradeID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if stream.Len() != 0 {
+		t.Errorf("Encode wrote %d bytes before Flush, want 0", stream.Len())
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	afterFirst := stream.Len()
+	if afterFirst == 0 {
+		t.Fatal("Flush wrote nothing")
+	}
+
+	// Messages straddling the flush boundary all arrive intact.
+	if err := e.Encode(MsgTypeTrade, TradeBody{TradeID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err) // idempotent with nothing pending
+	}
+
+	d := NewDecoder(&stream)
+	for want := uint32(1); want <= 2; want++ {
+		_, bodies, err := d.Decode()
+		if err != nil {
+			t.Fatalf("Decode trade %d: %v", want, err)
+		}
+		if got := bodies[0].(*TradeBody).TradeID; got != want {
+			t.Errorf("TradeID = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestEncoderAutoFlush(t *testing.T) {
+	var stream bytes.Buffer
+	e := NewEncoder(&stream)
+
+	// Enough messages to exceed the threshold force a write-through.
+	messages := encoderFlushThreshold/(HeaderSize+BodySizeFixed) + 1
+	for i := 0; i < messages; i++ {
+		if err := e.Encode(MsgTypeTrade, TradeBody{TradeID: uint32(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stream.Len() == 0 {
+		t.Error("no auto-flush after exceeding the threshold")
+	}
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestEncoderClose(t *testing.T) {
+	var sink closeRecorder
+	e := NewEncoder(&sink)
+
+	if err := e.Encode(MsgTypeTrade, TradeBody{TradeID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !sink.closed {
+		t.Error("Close did not close the underlying io.Closer")
+	}
+
+	d := NewDecoder(&sink.Buffer)
+	if _, bodies, err := d.Decode(); err != nil || bodies[0].(*TradeBody).TradeID != 7 {
+		t.Errorf("buffered message lost across Close: bodies=%v err=%v", bodies, err)
+	}
+
+	if err := e.Encode(MsgTypeTrade, TradeBody{}); err == nil {
+		t.Error("Encode after Close succeeded")
+	}
+	if err := e.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
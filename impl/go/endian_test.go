@@ -0,0 +1,76 @@
+package mitch
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+// TestWireBytesAreBigEndian pins the exact wire bytes of a known message.
+// The hex literal was written out by hand from the spec, so any code path
+// that silently falls back to native byte order breaks this test on
+// little-endian hosts — which is every platform we build on.
+func TestWireBytesAreBigEndian(t *testing.T) {
+	ts := time.Date(2025, time.January, 2, 0, 0, 0, 123456789, time.UTC)
+	trade := TradeBody{
+		TickerID: 0x1122334455667788,
+		Price:    1.5, // float64 bits 0x3FF8000000000000
+		Quantity: 0x0A0B0C0D,
+		TradeID:  0x01020304,
+		Side:     SideSell,
+	}
+	data, err := PackMessageAt(ts, MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = "74" + // 't'
+		"0000075bcd15" + // u48 timestamp: 123456789 ns after midnight
+		"01" + // count
+		"1122334455667788" + // ticker ID
+		"3ff8000000000000" + // price 1.5
+		"0a0b0c0d" + // quantity
+		"01020304" + // trade ID
+		"01" + // side: sell
+		"00000000000000" // padding
+	if got := hex.EncodeToString(data); got != want {
+		t.Fatalf("wire bytes:\ngot  %s\nwant %s", got, want)
+	}
+
+	// The spec decode path reads the same values back.
+	_, bodies, err := UnpackMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *bodies[0].(*TradeBody); got != trade {
+		t.Errorf("big-endian decode = %+v, want %+v", got, trade)
+	}
+}
+
+// TestLittleEndianDecodeDiffers decodes the big-endian wire bytes with
+// the opposite order and confirms the fields come out byte-swapped. If
+// some encoder ever emitted native-order bytes, the two decodes would
+// agree on a little-endian host and this test would catch it.
+func TestLittleEndianDecodeDiffers(t *testing.T) {
+	trade := TradeBody{TickerID: 0x1122334455667788, Quantity: 0x0A0B0C0D}
+	data, err := PackMessage(MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, bodies, err := UnpackMessageOrdered(binary.LittleEndian, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapped := bodies[0].(*TradeBody)
+	if swapped.TickerID == trade.TickerID {
+		t.Error("little-endian decode agreed with the big-endian encode; wire bytes are not big-endian")
+	}
+	if swapped.TickerID != 0x8877665544332211 {
+		t.Errorf("TickerID decoded little-endian = %#x, want the byte-swapped %#x", swapped.TickerID, uint64(0x8877665544332211))
+	}
+	if swapped.Quantity != 0x0D0C0B0A {
+		t.Errorf("Quantity decoded little-endian = %#x, want %#x", swapped.Quantity, uint32(0x0D0C0B0A))
+	}
+}
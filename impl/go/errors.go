@@ -0,0 +1,39 @@
+// Sentinel errors for programmatic matching.
+//
+// Failure paths wrap these with %w and add context, so callers can
+// distinguish a short buffer from an unknown message type with errors.Is
+// instead of string matching.
+
+package mitch
+
+import "errors"
+
+var (
+	// ErrShortBuffer marks input shorter than the structure it should hold.
+	ErrShortBuffer = errors.New("short buffer")
+
+	// ErrUnknownMessageType marks a message type byte outside the known set.
+	ErrUnknownMessageType = errors.New("unknown message type")
+
+	// ErrNoBodies marks a pack call with nothing to pack.
+	ErrNoBodies = errors.New("no bodies")
+
+	// ErrTooManyBodies marks a batch exceeding the 255-body frame limit.
+	ErrTooManyBodies = errors.New("too many bodies")
+
+	// ErrOrderBookNeedsSpecialUnpack marks an order book hitting the
+	// fixed-size unpack path; use UnpackOrderBook or UnpackAny.
+	ErrOrderBookNeedsSpecialUnpack = errors.New("order book requires a specialized unpack")
+
+	// ErrChecksumMismatch marks a CRC32 trailer that does not match its
+	// payload.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrMessageTooLarge marks a message whose claimed body size exceeds
+	// the Decoder's MaxBodyBytes limit.
+	ErrMessageTooLarge = errors.New("message too large")
+
+	// ErrRateLimited marks a publish rejected by a non-blocking rate
+	// limiter; retry later or switch the limiter to blocking mode.
+	ErrRateLimited = errors.New("rate limited")
+)
@@ -0,0 +1,53 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"pack no bodies", func() error { _, err := PackMessage(MsgTypeTrade); return err }(), ErrNoBodies},
+		{"unpack short buffer", func() error { _, _, err := UnpackMessage([]byte{'t'}); return err }(), ErrShortBuffer},
+		{"unpack order book bail", func() error {
+			message, _ := PackOrderBook(MitchHeader{}, OrderBookBody{NumTicks: 1}, []uint32{10})
+			_, _, err := UnpackMessage(message)
+			return err
+		}(), ErrOrderBookNeedsSpecialUnpack},
+		{"unpack unknown type", func() error {
+			_, _, err := UnpackMessage([]byte{'z', 0, 0, 0, 0, 0, 0, 1})
+			return err
+		}(), ErrUnknownMessageType},
+		{"checksum mismatch", func() error {
+			message, _ := PackWithChecksum(MsgTypeTrade, TradeBody{TradeID: 1})
+			message[10] ^= 0xFF
+			_, err := StripChecksum(message)
+			return err
+		}(), ErrChecksumMismatch},
+	}
+
+	for _, tc := range cases {
+		if tc.err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+			continue
+		}
+		if !errors.Is(tc.err, tc.want) {
+			t.Errorf("%s: errors.Is(%v, %v) = false", tc.name, tc.err, tc.want)
+		}
+	}
+}
+
+func TestTooManyBodies(t *testing.T) {
+	bodies := make([]interface{}, 256)
+	for i := range bodies {
+		bodies[i] = TradeBody{}
+	}
+	_, err := PackMessage(MsgTypeTrade, bodies...)
+	if !errors.Is(err, ErrTooManyBodies) {
+		t.Errorf("PackMessage with 256 bodies: errors.Is(%v, ErrTooManyBodies) = false", err)
+	}
+}
@@ -0,0 +1,41 @@
+// OrderBody expiry accessors.
+//
+// Expiry uses the same 48-bit nanoseconds-since-midnight convention as the
+// header timestamp, with all-zero bytes as the good-till-cancelled sentinel:
+// an order expiring exactly at midnight cannot be represented, which is a
+// non-issue in practice since no venue expires orders at 00:00:00.000000000.
+
+package mitch
+
+import "time"
+
+// HasExpiry reports whether the order carries an expiry. All-zero expiry
+// bytes mean good-till-cancelled.
+func (o *OrderBody) HasExpiry() bool {
+	return o.Expiry != ([6]byte{})
+}
+
+// ExpiryTime combines the expiry timestamp with the given day's UTC date
+// into an absolute time, mirroring TimestampToTime. For GTC orders it
+// returns the zero time; check HasExpiry first to distinguish.
+func (o *OrderBody) ExpiryTime(day time.Time) time.Time {
+	if !o.HasExpiry() {
+		return time.Time{}
+	}
+	return TimestampToTime(o.Expiry, day)
+}
+
+// SetExpiry stores the nanoseconds since t's UTC midnight as the order's
+// expiry. Passing the zero time clears the expiry back to GTC.
+func (o *OrderBody) SetExpiry(t time.Time) error {
+	if t.IsZero() {
+		o.Expiry = [6]byte{}
+		return nil
+	}
+	ts, err := TimeToTimestamp48(t)
+	if err != nil {
+		return err
+	}
+	o.Expiry = ts
+	return nil
+}
@@ -0,0 +1,36 @@
+package mitch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderExpiryRoundTrip(t *testing.T) {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+	expiry := day.Add(15*time.Hour + 30*time.Minute)
+
+	var order OrderBody
+	if order.HasExpiry() {
+		t.Error("zero-value order must be GTC")
+	}
+	if !order.ExpiryTime(day).IsZero() {
+		t.Error("GTC ExpiryTime must be the zero time")
+	}
+
+	if err := order.SetExpiry(expiry); err != nil {
+		t.Fatalf("SetExpiry: %v", err)
+	}
+	if !order.HasExpiry() {
+		t.Error("HasExpiry = false after SetExpiry")
+	}
+	if got := order.ExpiryTime(day); !got.Equal(expiry) {
+		t.Errorf("ExpiryTime = %v, want %v", got, expiry)
+	}
+
+	if err := order.SetExpiry(time.Time{}); err != nil {
+		t.Fatalf("SetExpiry(zero): %v", err)
+	}
+	if order.HasExpiry() {
+		t.Error("SetExpiry(zero) must clear back to GTC")
+	}
+}
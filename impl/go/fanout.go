@@ -0,0 +1,80 @@
+// Fanout of one feed to many downstream connections.
+//
+// Writes are serialized: Broadcast writes the frame to each connection in
+// turn under the fanout lock, with no per-connection buffering. A consumer
+// that cannot keep up eventually fills its kernel send buffer and its write
+// errors, at which point Broadcast drops it rather than stalling the rest;
+// callers needing elastic buffering should set write deadlines on the
+// connections or wrap them before registering.
+
+package mitch
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Fanout broadcasts frames to a set of registered connections. It is safe
+// for concurrent use.
+type Fanout struct {
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+// NewFanout returns an empty Fanout.
+func NewFanout() *Fanout {
+	return &Fanout{conns: make(map[net.Conn]struct{})}
+}
+
+// AddConn registers a connection to receive future broadcasts.
+func (f *Fanout) AddConn(conn net.Conn) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.conns[conn] = struct{}{}
+}
+
+// RemoveConn unregisters a connection. It does not close it.
+func (f *Fanout) RemoveConn(conn net.Conn) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.conns, conn)
+}
+
+// Len returns the number of registered connections.
+func (f *Fanout) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.conns)
+}
+
+// Broadcast writes data to every registered connection. A connection whose
+// write fails or comes up short is closed and dropped; the remaining
+// connections still receive the frame. The returned error wraps the first
+// failure and reports how many connections were dropped, or is nil if all
+// writes succeeded.
+func (f *Fanout) Broadcast(data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var firstErr error
+	dropped := 0
+	for conn := range f.conns {
+		n, err := conn.Write(data)
+		if err == nil && n < len(data) {
+			err = fmt.Errorf("mitch: fanout: short write %d of %d bytes", n, len(data))
+		}
+		if err != nil {
+			conn.Close()
+			delete(f.conns, conn)
+			dropped++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("mitch: fanout: dropped %d connection(s): %w", dropped, firstErr)
+	}
+	return nil
+}
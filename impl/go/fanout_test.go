@@ -0,0 +1,93 @@
+package mitch
+
+import (
+	"net"
+	"testing"
+)
+
+// pairedConn returns the two ends of an in-memory connection with the read
+// side drained into a channel.
+func drainConn(t *testing.T, conn net.Conn) <-chan []byte {
+	t.Helper()
+	out := make(chan []byte, 16)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				close(out)
+				return
+			}
+			out <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	return out
+}
+
+func TestFanoutBroadcast(t *testing.T) {
+	f := NewFanout()
+	clientA, serverA := net.Pipe()
+	clientB, serverB := net.Pipe()
+	defer clientA.Close()
+	defer clientB.Close()
+	f.AddConn(serverA)
+	f.AddConn(serverB)
+
+	recvA := drainConn(t, clientA)
+	recvB := drainConn(t, clientB)
+
+	frame := []byte{1, 2, 3, 4}
+	if err := f.Broadcast(frame); err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	if got := <-recvA; string(got) != string(frame) {
+		t.Errorf("conn A got % X, want % X", got, frame)
+	}
+	if got := <-recvB; string(got) != string(frame) {
+		t.Errorf("conn B got % X, want % X", got, frame)
+	}
+}
+
+func TestFanoutDropsFailedConn(t *testing.T) {
+	f := NewFanout()
+	clientA, serverA := net.Pipe()
+	clientB, serverB := net.Pipe()
+	defer clientA.Close()
+	f.AddConn(serverA)
+	f.AddConn(serverB)
+
+	recvA := drainConn(t, clientA)
+	// Closing the healthy peer of B makes writes to serverB fail.
+	clientB.Close()
+	serverB.Close()
+
+	if err := f.Broadcast([]byte{9}); err == nil {
+		t.Fatal("Broadcast must report the dropped connection")
+	}
+	if f.Len() != 1 {
+		t.Fatalf("Len = %d after drop, want 1", f.Len())
+	}
+
+	// The survivor keeps receiving.
+	if err := f.Broadcast([]byte{7}); err != nil {
+		t.Fatalf("Broadcast after drop: %v", err)
+	}
+	<-recvA
+	if got := <-recvA; len(got) != 1 || got[0] != 7 {
+		t.Errorf("survivor got % X, want 07", got)
+	}
+}
+
+func TestFanoutRemoveConn(t *testing.T) {
+	f := NewFanout()
+	client, server := net.Pipe()
+	defer client.Close()
+	f.AddConn(server)
+	f.RemoveConn(server)
+	if f.Len() != 0 {
+		t.Fatalf("Len = %d, want 0", f.Len())
+	}
+	if err := f.Broadcast([]byte{1}); err != nil {
+		t.Fatalf("Broadcast to empty fanout: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package mitch
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeCapture(t *testing.T, messages ...[]byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "capture.mitch")
+	var data []byte
+	for _, m := range messages {
+		data = append(data, m...)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileReaderReplay(t *testing.T) {
+	trades, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1}, TradeBody{TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 3, FirstTick: 10, TickSize: 1, NumTicks: 4, Side: SideSell},
+		[]uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticker, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := OpenFileReader(writeCapture(t, trades, book, ticker))
+	if err != nil {
+		t.Fatalf("OpenFileReader: %v", err)
+	}
+	defer reader.Close()
+
+	wantTypes := []MessageType{MsgTypeTrade, MsgTypeOrderBook, MsgTypeTicker}
+	for i, want := range wantTypes {
+		header, bodies, err := reader.Decode()
+		if err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+		if header.MessageType != want {
+			t.Errorf("message %d type = %q, want %q", i, header.MessageType, want)
+		}
+		if want == MsgTypeOrderBook {
+			entry := bodies[0].(*OrderBookEntry)
+			if len(entry.Volumes) != 4 {
+				t.Errorf("book has %d volumes, want 4", len(entry.Volumes))
+			}
+		}
+	}
+	if _, _, err := reader.Decode(); !errors.Is(err, io.EOF) {
+		t.Errorf("end of capture: got %v, want io.EOF", err)
+	}
+}
+
+func TestFileReaderSpeedControl(t *testing.T) {
+	// Two messages 40ms apart in feed time, replayed at 2x: expect ~20ms.
+	first, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := append([]byte(nil), first...)
+	ts := ReadTimestamp48([6]byte(first[1:7]))
+	stamped := WriteTimestamp48(ts + 40_000_000)
+	copy(second[1:7], stamped[:])
+
+	reader, err := OpenFileReader(writeCapture(t, first, second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	reader.SetSpeed(2.0)
+
+	if _, _, err := reader.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, _, err := reader.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("second Decode returned after %v, want >= ~20ms pacing", elapsed)
+	}
+}
+
+func TestFileReaderMissingFile(t *testing.T) {
+	if _, err := OpenFileReader(filepath.Join(t.TempDir(), "absent")); err == nil {
+		t.Error("opening a missing file must error")
+	}
+}
@@ -0,0 +1,75 @@
+// Instrument filtering for narrowly-scoped consumers.
+//
+// A shared feed carries thousands of instruments; a consumer caring about
+// a handful still pays to route and process every body. FilterDecoder
+// drops non-matching bodies right after decode: batches are trimmed
+// body-by-body, messages left empty are skipped entirely, and the loop
+// continues until something relevant (or an error) comes up, so callers
+// never see a body they did not ask for.
+
+package mitch
+
+// FilterDecoder wraps a Decoder and returns only bodies whose TickerID is
+// in its set. Control messages without an instrument — heartbeats — pass
+// through unfiltered. Not safe for concurrent use, like the Decoder it
+// wraps.
+type FilterDecoder struct {
+	decoder *Decoder
+	ids     map[uint64]struct{}
+}
+
+// NewFilterDecoder returns a FilterDecoder passing through only the given
+// TickerIDs.
+func NewFilterDecoder(decoder *Decoder, tickerIDs ...uint64) *FilterDecoder {
+	ids := make(map[uint64]struct{}, len(tickerIDs))
+	for _, id := range tickerIDs {
+		ids[id] = struct{}{}
+	}
+	return &FilterDecoder{decoder: decoder, ids: ids}
+}
+
+// Decode returns the next message containing at least one matching body,
+// with non-matching bodies trimmed from the batch and the header's Count
+// adjusted to match. Messages with no matching bodies are discarded and
+// decoding continues.
+func (f *FilterDecoder) Decode() (*MitchHeader, []interface{}, error) {
+	for {
+		header, bodies, err := f.decoder.Decode()
+		if err != nil {
+			return nil, nil, err
+		}
+		kept := bodies[:0]
+		for _, body := range bodies {
+			if f.matches(body) {
+				kept = append(kept, body)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		header.Count = uint8(len(kept))
+		return header, kept, nil
+	}
+}
+
+// matches reports whether the body's instrument is in the filter set.
+// Bodies without a TickerID always match.
+func (f *FilterDecoder) matches(body interface{}) bool {
+	var id uint64
+	switch v := body.(type) {
+	case *TradeBody:
+		id = v.TickerID
+	case *OrderBody:
+		id = v.TickerID
+	case *TickerBody:
+		id = v.TickerID
+	case *OrderBookEntry:
+		id = v.Body.TickerID
+	case *BookChecksumBody:
+		id = v.TickerID
+	default:
+		return true
+	}
+	_, ok := f.ids[id]
+	return ok
+}
@@ -0,0 +1,104 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFilterDecoderTrimsBatches(t *testing.T) {
+	var stream bytes.Buffer
+	message, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, TradeID: 10},
+		TradeBody{TickerID: 2, TradeID: 20},
+		TradeBody{TickerID: 1, TradeID: 11},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(message)
+
+	f := NewFilterDecoder(NewDecoder(&stream), 1)
+	header, bodies, err := f.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Count != 2 || len(bodies) != 2 {
+		t.Fatalf("kept %d bodies (Count %d), want 2", len(bodies), header.Count)
+	}
+	for i, want := range []uint32{10, 11} {
+		if got := bodies[i].(*TradeBody).TradeID; got != want {
+			t.Errorf("bodies[%d].TradeID = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestFilterDecoderSkipsEmptyMessages(t *testing.T) {
+	var stream bytes.Buffer
+	other, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 5, BidPrice: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 9, BidPrice: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(other)
+	stream.Write(wanted)
+
+	f := NewFilterDecoder(NewDecoder(&stream), 9)
+	_, bodies, err := f.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bodies[0].(*TickerBody).TickerID != 9 {
+		t.Errorf("got ticker %d, want 9", bodies[0].(*TickerBody).TickerID)
+	}
+	if _, _, err := f.Decode(); !errors.Is(err, io.EOF) {
+		t.Errorf("drained stream = %v, want io.EOF", err)
+	}
+}
+
+func TestFilterDecoderPassesHeartbeats(t *testing.T) {
+	var stream bytes.Buffer
+	heartbeat, err := PackHeartbeat(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(heartbeat)
+
+	f := NewFilterDecoder(NewDecoder(&stream), 1)
+	header, _, err := f.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.MessageType != MsgTypeHeartbeat {
+		t.Errorf("message type = %q, want heartbeat", header.MessageType)
+	}
+}
+
+func TestFilterDecoderOrderBooks(t *testing.T) {
+	var stream bytes.Buffer
+	books := []OrderBookBody{
+		{TickerID: 1, FirstTick: 10, TickSize: 1, NumTicks: 2, Side: SideBuy},
+		{TickerID: 2, FirstTick: 20, TickSize: 1, NumTicks: 2, Side: SideSell},
+	}
+	message, err := PackOrderBookBatch(books, [][]uint32{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(message)
+
+	f := NewFilterDecoder(NewDecoder(&stream), 2)
+	_, bodies, err := f.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("kept %d books, want 1", len(bodies))
+	}
+	if got := bodies[0].(*OrderBookEntry).Body.TickerID; got != 2 {
+		t.Errorf("kept book for ticker %d, want 2", got)
+	}
+}
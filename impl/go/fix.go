@@ -0,0 +1,75 @@
+// FIX 4.4 to MITCH trade conversion.
+//
+// Gateways bridging an existing FIX feed parse each ExecutionReport into a
+// tag-to-value map; TradeFromFIX maps the common tags into a TradeBody so
+// the gateway can emit MITCH trades without touching the raw FIX encoding.
+
+package mitch
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FIX tag numbers consumed by TradeFromFIX.
+const (
+	fixTagTradeID = 17 // ExecID
+	fixTagLastPx  = 31
+	fixTagLastQty = 32
+	fixTagSide    = 54
+	fixTagSymbol  = 55
+)
+
+// TradeFromFIX converts a parsed FIX 4.4 ExecutionReport into a TradeBody.
+// Tag 55 (Symbol) is resolved through the registry, registering the symbol
+// on first sight; tag 31 (LastPx) maps to Price, tag 32 (LastQty) to
+// Quantity and tag 54 (Side, 1=Buy/2=Sell) to Side. Tag 17 (ExecID) fills
+// TradeID when it parses as an unsigned integer and is otherwise left zero.
+// Missing or malformed required tags error with the tag number named.
+func (r *SymbolRegistry) TradeFromFIX(fields map[int]string) (TradeBody, error) {
+	var trade TradeBody
+
+	symbol, ok := fields[fixTagSymbol]
+	if !ok || symbol == "" {
+		return trade, fmt.Errorf("mitch: fix: missing tag 55 (Symbol)")
+	}
+	trade.TickerID = r.Register(symbol)
+
+	px, ok := fields[fixTagLastPx]
+	if !ok {
+		return trade, fmt.Errorf("mitch: fix: missing tag 31 (LastPx)")
+	}
+	price, err := strconv.ParseFloat(px, 64)
+	if err != nil {
+		return trade, fmt.Errorf("mitch: fix: bad tag 31 (LastPx) %q: %w", px, err)
+	}
+	trade.Price = price
+
+	qty, ok := fields[fixTagLastQty]
+	if !ok {
+		return trade, fmt.Errorf("mitch: fix: missing tag 32 (LastQty)")
+	}
+	quantity, err := strconv.ParseUint(qty, 10, 32)
+	if err != nil {
+		return trade, fmt.Errorf("mitch: fix: bad tag 32 (LastQty) %q: %w", qty, err)
+	}
+	trade.Quantity = uint32(quantity)
+
+	switch fields[fixTagSide] {
+	case "1":
+		trade.Side = SideBuy
+	case "2":
+		trade.Side = SideSell
+	case "":
+		return trade, fmt.Errorf("mitch: fix: missing tag 54 (Side)")
+	default:
+		return trade, fmt.Errorf("mitch: fix: bad tag 54 (Side) %q: want 1 or 2", fields[fixTagSide])
+	}
+
+	if execID, ok := fields[fixTagTradeID]; ok {
+		if id, err := strconv.ParseUint(execID, 10, 32); err == nil {
+			trade.TradeID = uint32(id)
+		}
+	}
+	return trade, nil
+}
@@ -0,0 +1,74 @@
+package mitch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTradeFromFIX(t *testing.T) {
+	registry := NewSymbolRegistry()
+	fields := map[int]string{
+		17: "123456",
+		31: "1.0845",
+		32: "5000",
+		54: "2",
+		55: "EURUSD",
+	}
+	trade, err := registry.TradeFromFIX(fields)
+	if err != nil {
+		t.Fatalf("TradeFromFIX: %v", err)
+	}
+	if id, _ := registry.ID("EURUSD"); trade.TickerID != id {
+		t.Errorf("TickerID = %d, want registry ID %d", trade.TickerID, id)
+	}
+	if trade.Price != 1.0845 || trade.Quantity != 5000 || trade.Side != SideSell || trade.TradeID != 123456 {
+		t.Errorf("trade = %+v", trade)
+	}
+}
+
+func TestTradeFromFIXErrors(t *testing.T) {
+	registry := NewSymbolRegistry()
+	base := map[int]string{31: "1.5", 32: "10", 54: "1", 55: "GBPUSD"}
+
+	cases := []struct {
+		name    string
+		mutate  func(map[int]string)
+		wantTag string
+	}{
+		{"missing symbol", func(f map[int]string) { delete(f, 55) }, "tag 55"},
+		{"missing price", func(f map[int]string) { delete(f, 31) }, "tag 31"},
+		{"bad price", func(f map[int]string) { f[31] = "abc" }, "tag 31"},
+		{"missing quantity", func(f map[int]string) { delete(f, 32) }, "tag 32"},
+		{"bad quantity", func(f map[int]string) { f[32] = "-4" }, "tag 32"},
+		{"missing side", func(f map[int]string) { delete(f, 54) }, "tag 54"},
+		{"bad side", func(f map[int]string) { f[54] = "9" }, "tag 54"},
+	}
+	for _, tc := range cases {
+		fields := make(map[int]string, len(base))
+		for k, v := range base {
+			fields[k] = v
+		}
+		tc.mutate(fields)
+		_, err := registry.TradeFromFIX(fields)
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.wantTag) {
+			t.Errorf("%s: error %q does not name %s", tc.name, err, tc.wantTag)
+		}
+	}
+}
+
+func TestTradeFromFIXNonNumericExecID(t *testing.T) {
+	registry := NewSymbolRegistry()
+	trade, err := registry.TradeFromFIX(map[int]string{
+		17: "EXEC-A1", 31: "2.5", 32: "1", 54: "1", 55: "USDJPY",
+	})
+	if err != nil {
+		t.Fatalf("TradeFromFIX: %v", err)
+	}
+	if trade.TradeID != 0 {
+		t.Errorf("non-numeric ExecID should leave TradeID zero, got %d", trade.TradeID)
+	}
+}
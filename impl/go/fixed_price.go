@@ -0,0 +1,57 @@
+// Fixed-point price representation.
+//
+// float64 prices suffer rounding and are not bit-stable across platforms,
+// which matters for exact price matching. FixedPrice is an int64 mantissa
+// at a fixed 1e-9 scale: deterministic, totally ordered and safe to compare
+// with ==. Negative prices (spreads, basis quotes) are preserved.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+)
+
+// FixedPriceScale is the value of one FixedPrice unit.
+const FixedPriceScale = 1e-9
+
+// FixedPrice is a price as an integer count of 1e-9 units. The
+// representable range is about ±9.2e9 at full nanoprecision.
+type FixedPrice int64
+
+// fixedPriceUnits is the number of FixedPrice units per 1.0 of price.
+const fixedPriceUnits = 1e9
+
+// ToFixed converts a float64 price to FixedPrice, rounding half away from
+// zero at the 1e-9 boundary.
+func ToFixed(f float64) FixedPrice {
+	return FixedPrice(math.Round(f * fixedPriceUnits))
+}
+
+// ToFloat converts a FixedPrice back to float64.
+func ToFloat(p FixedPrice) float64 {
+	return float64(p) / fixedPriceUnits
+}
+
+// String formats the price with full nanoprecision.
+func (p FixedPrice) String() string {
+	return fmt.Sprintf("%.9f", ToFloat(p))
+}
+
+// FixedPrice returns the trade price as a deterministic fixed-point value.
+func (t *TradeBody) FixedPrice() FixedPrice { return ToFixed(t.Price) }
+
+// SetFixedPrice sets the trade price from a fixed-point value.
+func (t *TradeBody) SetFixedPrice(p FixedPrice) { t.Price = ToFloat(p) }
+
+// FixedPrice returns the order price as a deterministic fixed-point value.
+func (o *OrderBody) FixedPrice() FixedPrice { return ToFixed(o.Price) }
+
+// SetFixedPrice sets the order price from a fixed-point value.
+func (o *OrderBody) SetFixedPrice(p FixedPrice) { o.Price = ToFloat(p) }
+
+// FixedBid returns the bid price as a deterministic fixed-point value.
+func (t *TickerBody) FixedBid() FixedPrice { return ToFixed(t.BidPrice) }
+
+// FixedAsk returns the ask price as a deterministic fixed-point value.
+func (t *TickerBody) FixedAsk() FixedPrice { return ToFixed(t.AskPrice) }
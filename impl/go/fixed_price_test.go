@@ -0,0 +1,45 @@
+package mitch
+
+import "testing"
+
+func TestToFixedRounding(t *testing.T) {
+	cases := []struct {
+		in   float64
+		want FixedPrice
+	}{
+		{1.0, 1_000_000_000},
+		{0.000000001, 1},
+		{0.0000000014, 1}, // rounds down at the scale boundary
+		{0.0000000015, 2}, // rounds half away from zero
+		{-1.5, -1_500_000_000},
+		{-0.0000000015, -2}, // half away from zero holds for negatives
+		{0, 0},
+	}
+	for _, tc := range cases {
+		if got := ToFixed(tc.in); got != tc.want {
+			t.Errorf("ToFixed(%v) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestFixedPriceRoundTrip(t *testing.T) {
+	prices := []float64{1.08551, 50000.5, -0.25, 0.000012345}
+	for _, p := range prices {
+		if got := ToFloat(ToFixed(p)); got != p {
+			t.Errorf("round trip %v = %v", p, got)
+		}
+	}
+}
+
+func TestBodyFixedPriceAccessors(t *testing.T) {
+	var trade TradeBody
+	trade.SetFixedPrice(ToFixed(99.999))
+	if trade.FixedPrice() != ToFixed(99.999) {
+		t.Errorf("trade fixed price = %v", trade.FixedPrice())
+	}
+
+	ticker := TickerBody{BidPrice: 1.1, AskPrice: 1.2}
+	if ticker.FixedBid() >= ticker.FixedAsk() {
+		t.Errorf("fixed bid %v not below fixed ask %v", ticker.FixedBid(), ticker.FixedAsk())
+	}
+}
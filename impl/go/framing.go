@@ -0,0 +1,110 @@
+// Length-prefixed framing for robust stream parsing.
+//
+// The MITCH header has no total-length field, so a stream parser must know
+// each body's size to frame correctly. These helpers prepend a 4-byte
+// big-endian total-length prefix before the header so a receiver can frame
+// any message without understanding its type. This is a transport-level
+// wrapper outside the MITCH spec: the prefix never appears on spec-compliant
+// feeds and both ends must agree to use it.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+)
+
+// FramePrefixSize is the size of the length prefix in bytes.
+const FramePrefixSize = 4
+
+// maxFrameLength bounds the length prefix accepted by ReadFrame so a
+// corrupt prefix cannot force a huge allocation.
+const maxFrameLength = 16 << 20
+
+// frameRetransmitFlag is the retransmit marker in the length prefix.
+// Frames cap at maxFrameLength, far below 2^31, so the prefix's top bit is
+// free to carry a flag without changing any body layout: gap-fill servers
+// set it on replayed messages and consumers apply them idempotently.
+const frameRetransmitFlag = 1 << 31
+
+// PackFramed prepends a 4-byte big-endian length prefix to an
+// already-packed MITCH message. The prefix counts only the inner message
+// bytes, not itself.
+func PackFramed(message []byte) ([]byte, error) {
+	if len(message) < HeaderSize {
+		return nil, fmt.Errorf("mitch: message too short to frame: %d bytes (need %d)", len(message), HeaderSize)
+	}
+	framed := make([]byte, FramePrefixSize+len(message))
+	ByteOrder.PutUint32(framed[0:4], uint32(len(message)))
+	copy(framed[FramePrefixSize:], message)
+	return framed, nil
+}
+
+// UnpackFramed strips the 4-byte length prefix and returns the inner
+// message, validating the prefix against the buffer length.
+func UnpackFramed(data []byte) ([]byte, error) {
+	if len(data) < FramePrefixSize {
+		return nil, fmt.Errorf("mitch: %w: framed data is %d bytes (need %d)", ErrShortBuffer, len(data), FramePrefixSize)
+	}
+	length := int(ByteOrder.Uint32(data[0:4]) &^ frameRetransmitFlag)
+	if len(data) < FramePrefixSize+length {
+		return nil, fmt.Errorf("mitch: %w: frame prefix claims %d bytes, %d remain", ErrShortBuffer, length, len(data)-FramePrefixSize)
+	}
+	return data[FramePrefixSize : FramePrefixSize+length], nil
+}
+
+// SetRetransmit marks or unmarks a framed message as a retransmission by
+// toggling the prefix flag bit. The inner message bytes are untouched.
+func SetRetransmit(framed []byte, retransmit bool) error {
+	if len(framed) < FramePrefixSize {
+		return fmt.Errorf("mitch: %w: framed data is %d bytes (need %d)", ErrShortBuffer, len(framed), FramePrefixSize)
+	}
+	prefix := ByteOrder.Uint32(framed[0:4])
+	if retransmit {
+		prefix |= frameRetransmitFlag
+	} else {
+		prefix &^= frameRetransmitFlag
+	}
+	ByteOrder.PutUint32(framed[0:4], prefix)
+	return nil
+}
+
+// IsRetransmit reports whether a framed message carries the retransmit
+// marker.
+func IsRetransmit(framed []byte) (bool, error) {
+	if len(framed) < FramePrefixSize {
+		return false, fmt.Errorf("mitch: %w: framed data is %d bytes (need %d)", ErrShortBuffer, len(framed), FramePrefixSize)
+	}
+	return ByteOrder.Uint32(framed[0:4])&frameRetransmitFlag != 0, nil
+}
+
+// ReadFrame reads one length-prefixed message from r and returns the
+// complete inner message bytes. It returns io.EOF at a clean stream end.
+func ReadFrame(r io.Reader) ([]byte, error) {
+	message, _, err := ReadFrameFlagged(r)
+	return message, err
+}
+
+// ReadFrameFlagged reads one length-prefixed message from r, additionally
+// reporting whether the frame carries the retransmit marker so consumers
+// of gap-filled feeds can deduplicate.
+func ReadFrameFlagged(r io.Reader) ([]byte, bool, error) {
+	var prefix [FramePrefixSize]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, false, err
+	}
+	raw := ByteOrder.Uint32(prefix[:])
+	retransmit := raw&frameRetransmitFlag != 0
+	length := raw &^ frameRetransmitFlag
+	if length < HeaderSize || length > maxFrameLength {
+		return nil, false, fmt.Errorf("mitch: invalid frame length %d", length)
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(r, message); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, false, err
+	}
+	return message, retransmit, nil
+}
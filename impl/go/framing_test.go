@@ -0,0 +1,119 @@
+package mitch
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestFramedRoundTrip(t *testing.T) {
+	message, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 1, BidPrice: 2, AskPrice: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	framed, err := PackFramed(message)
+	if err != nil {
+		t.Fatalf("PackFramed failed: %v", err)
+	}
+	if len(framed) != FramePrefixSize+len(message) {
+		t.Errorf("framed length = %d, want %d", len(framed), FramePrefixSize+len(message))
+	}
+	inner, err := UnpackFramed(framed)
+	if err != nil {
+		t.Fatalf("UnpackFramed failed: %v", err)
+	}
+	if !bytes.Equal(inner, message) {
+		t.Errorf("inner = %x, want %x", inner, message)
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	var stream bytes.Buffer
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 1, FirstTick: 1, TickSize: 1, NumTicks: 3, Side: SideBuy},
+		[]uint32{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	framed, err := PackFramed(book)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(framed)
+	stream.Write(framed)
+
+	for i := 0; i < 2; i++ {
+		got, err := ReadFrame(&stream)
+		if err != nil {
+			t.Fatalf("ReadFrame %d failed: %v", i, err)
+		}
+		if !bytes.Equal(got, book) {
+			t.Errorf("frame %d = %x, want %x", i, got, book)
+		}
+	}
+	if _, err := ReadFrame(&stream); err != io.EOF {
+		t.Errorf("at end of stream err = %v, want io.EOF", err)
+	}
+}
+
+func TestUnpackFramedTruncated(t *testing.T) {
+	message, err := PackMessage(MsgTypeTrade, TradeBody{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	framed, err := PackFramed(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnpackFramed(framed[:len(framed)-1]); err == nil {
+		t.Error("expected error for truncated frame")
+	}
+	if _, err := UnpackFramed(framed[:2]); err == nil {
+		t.Error("expected error for truncated prefix")
+	}
+}
+
+func TestRetransmitFlag(t *testing.T) {
+	inner, err := PackHeartbeat(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	framed, err := PackFramed(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if on, err := IsRetransmit(framed); err != nil || on {
+		t.Fatalf("fresh frame: retransmit = %v, %v", on, err)
+	}
+	if err := SetRetransmit(framed, true); err != nil {
+		t.Fatalf("SetRetransmit: %v", err)
+	}
+	if on, _ := IsRetransmit(framed); !on {
+		t.Error("flag did not stick")
+	}
+
+	// The flag must not disturb length handling or the inner bytes.
+	unpacked, err := UnpackFramed(framed)
+	if err != nil {
+		t.Fatalf("UnpackFramed with flag: %v", err)
+	}
+	if !bytes.Equal(unpacked, inner) {
+		t.Error("inner message corrupted by flag")
+	}
+
+	message, retransmit, err := ReadFrameFlagged(bytes.NewReader(framed))
+	if err != nil {
+		t.Fatalf("ReadFrameFlagged: %v", err)
+	}
+	if !retransmit || !bytes.Equal(message, inner) {
+		t.Errorf("retransmit = %v, message match = %v", retransmit, bytes.Equal(message, inner))
+	}
+
+	if err := SetRetransmit(framed, false); err != nil {
+		t.Fatal(err)
+	}
+	if on, _ := IsRetransmit(framed); on {
+		t.Error("flag did not clear")
+	}
+}
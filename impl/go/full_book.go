@@ -0,0 +1,55 @@
+// Two-sided order book packing.
+//
+// One OrderBookBody carries a single side, so publishing a full book as
+// two messages lets a consumer observe a half-updated book between them.
+// PackFullBook puts both sides in one Count=2 frame, making the pair
+// atomic on the wire.
+
+package mitch
+
+import "fmt"
+
+// PackFullBook packs both sides of one instrument's book into a single
+// frame, bids first. The sides must agree on TickerID and carry their
+// conventional Side values (bids buy, asks sell).
+func PackFullBook(bids OrderBookBody, bidVols []uint32, asks OrderBookBody, askVols []uint32) ([]byte, error) {
+	if bids.TickerID != asks.TickerID {
+		return nil, fmt.Errorf("mitch: full book sides disagree on ticker: %d vs %d", bids.TickerID, asks.TickerID)
+	}
+	if bids.Side != SideBuy {
+		return nil, fmt.Errorf("mitch: full book bids carry side %d, want %d", bids.Side, SideBuy)
+	}
+	if asks.Side != SideSell {
+		return nil, fmt.Errorf("mitch: full book asks carry side %d, want %d", asks.Side, SideSell)
+	}
+	return PackOrderBookBatch([]OrderBookBody{bids, asks}, [][]uint32{bidVols, askVols})
+}
+
+// UnpackFullBook deserializes a two-sided book frame, routing the bodies
+// by their Side field so the order on the wire does not matter. It errors
+// unless the frame holds exactly one bid side and one ask side for the
+// same instrument.
+func UnpackFullBook(data []byte) (header *MitchHeader, bids *OrderBookBody, bidVols []uint32, asks *OrderBookBody, askVols []uint32, err error) {
+	header, books, volumes, err := UnpackOrderBookBatch(data)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	if len(books) != 2 {
+		return nil, nil, nil, nil, nil, fmt.Errorf("mitch: full book frame holds %d books, want 2", len(books))
+	}
+	for i, book := range books {
+		switch book.Side {
+		case SideBuy:
+			bids, bidVols = book, volumes[i]
+		case SideSell:
+			asks, askVols = book, volumes[i]
+		}
+	}
+	if bids == nil || asks == nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("mitch: full book frame is missing a side")
+	}
+	if bids.TickerID != asks.TickerID {
+		return nil, nil, nil, nil, nil, fmt.Errorf("mitch: full book sides disagree on ticker: %d vs %d", bids.TickerID, asks.TickerID)
+	}
+	return header, bids, bidVols, asks, askVols, nil
+}
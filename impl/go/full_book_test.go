@@ -0,0 +1,55 @@
+package mitch
+
+import "testing"
+
+func TestFullBookRoundTrip(t *testing.T) {
+	bids := OrderBookBody{TickerID: 7, FirstTick: 99.5, TickSize: 0.5, NumTicks: 2, Side: SideBuy}
+	asks := OrderBookBody{TickerID: 7, FirstTick: 100.0, TickSize: 0.5, NumTicks: 3, Side: SideSell}
+
+	frame, err := PackFullBook(bids, []uint32{10, 20}, asks, []uint32{5, 15, 25})
+	if err != nil {
+		t.Fatalf("PackFullBook: %v", err)
+	}
+
+	header, gotBids, bidVols, gotAsks, askVols, err := UnpackFullBook(frame)
+	if err != nil {
+		t.Fatalf("UnpackFullBook: %v", err)
+	}
+	if header.Count != 2 {
+		t.Errorf("Count = %d, want 2", header.Count)
+	}
+	if gotBids.NumTicks != 2 || len(bidVols) != 2 || bidVols[1] != 20 {
+		t.Errorf("bids = %+v vols %v", gotBids, bidVols)
+	}
+	if gotAsks.NumTicks != 3 || len(askVols) != 3 || askVols[2] != 25 {
+		t.Errorf("asks = %+v vols %v", gotAsks, askVols)
+	}
+}
+
+func TestPackFullBookValidation(t *testing.T) {
+	bids := OrderBookBody{TickerID: 1, FirstTick: 99, TickSize: 1, NumTicks: 1, Side: SideBuy}
+	asks := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 1, Side: SideSell}
+
+	mismatched := asks
+	mismatched.TickerID = 2
+	if _, err := PackFullBook(bids, []uint32{1}, mismatched, []uint32{1}); err == nil {
+		t.Error("mismatched tickers must error")
+	}
+
+	wrongSide := asks
+	wrongSide.Side = SideBuy
+	if _, err := PackFullBook(bids, []uint32{1}, wrongSide, []uint32{1}); err == nil {
+		t.Error("two bid sides must error")
+	}
+}
+
+func TestUnpackFullBookRejectsSingleSide(t *testing.T) {
+	book := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 1, Side: SideBuy}
+	frame, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, _, err := UnpackFullBook(frame); err == nil {
+		t.Error("single-side frame must error")
+	}
+}
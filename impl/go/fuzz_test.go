@@ -0,0 +1,41 @@
+package mitch
+
+import "testing"
+
+// FuzzUnpackMessage feeds arbitrary bytes through UnpackMessage (and the
+// dispatching UnpackAny) asserting the functions error instead of
+// panicking. The seeds cover the shapes that historically relied on
+// per-body read errors: a large Count over a short buffer, truncated
+// headers and truncated heartbeat batches.
+func FuzzUnpackMessage(f *testing.F) {
+	valid, _ := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1, TradeID: 2})
+	f.Add(valid)
+	f.Add([]byte{})
+	f.Add([]byte{'t'})
+	// Count=255 with no bodies at all.
+	f.Add([]byte{'t', 0, 0, 0, 0, 0, 0, 255})
+	f.Add([]byte{'o', 0, 0, 0, 0, 0, 0, 255, 1, 2, 3})
+	// Order book claiming more ticks than the buffer holds.
+	f.Add([]byte{'b', 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 1, // TickerID
+		0, 0, 0, 0, 0, 0, 0, 0, // FirstTick
+		0, 0, 0, 0, 0, 0, 0, 0, // TickSize
+		0xFF, 0xFF, // NumTicks = 65535
+		0, 0, 0, 0, 0, 0})
+	// Heartbeat batch cut mid-body.
+	f.Add([]byte{'h', 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 1, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		header, bodies, err := UnpackMessage(data)
+		if err == nil && header == nil {
+			t.Error("UnpackMessage returned nil header without error")
+		}
+		_ = bodies
+
+		// UnpackAny routes order books and heartbeats through their own
+		// hand-rolled parsers, which must be equally panic-free.
+		if _, _, err := UnpackAny(data); err == nil && len(data) < HeaderSize {
+			t.Error("UnpackAny accepted a short buffer")
+		}
+	})
+}
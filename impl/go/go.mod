@@ -0,0 +1,3 @@
+module github.com/btr-supply/mitch/impl/go
+
+go 1.21
@@ -0,0 +1,100 @@
+package mitch
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+// GoldenBytes encodes a body byte by byte from the spec layout, sharing no
+// code with binary.Write or the Append/Marshal paths. If an encoder and
+// this function agree, the bytes follow the spec and not merely each other.
+func GoldenBytes(body interface{}) ([]byte, error) {
+	switch b := body.(type) {
+	case TradeBody:
+		out := goldenU64(b.TickerID)
+		out = append(out, goldenU64(math.Float64bits(b.Price))...)
+		out = append(out, goldenU32(b.Quantity)...)
+		out = append(out, goldenU32(b.TradeID)...)
+		out = append(out, byte(b.Side))
+		// Padding goes out zeroed except the reserved synthetic flag.
+		return append(out, b.Padding[0]&synthTradeFlag, 0, 0, 0, 0, 0, 0), nil
+	case OrderBody:
+		out := goldenU64(b.TickerID)
+		out = append(out, goldenU32(b.OrderID)...)
+		out = append(out, goldenU64(math.Float64bits(b.Price))...)
+		out = append(out, goldenU32(b.Quantity)...)
+		out = append(out, b.TypeAndSide)
+		out = append(out, b.Expiry[:]...)
+		return append(out, 0), nil // padding carries no flags
+	case TickerBody:
+		out := goldenU64(b.TickerID)
+		out = append(out, goldenU64(math.Float64bits(b.BidPrice))...)
+		out = append(out, goldenU64(math.Float64bits(b.AskPrice))...)
+		out = append(out, goldenU32(b.BidVolume)...)
+		return append(out, goldenU32(b.AskVolume)...), nil
+	case OrderBookBody:
+		out := goldenU64(b.TickerID)
+		out = append(out, goldenU64(math.Float64bits(b.FirstTick))...)
+		out = append(out, goldenU64(math.Float64bits(b.TickSize))...)
+		out = append(out, byte(b.NumTicks>>8), byte(b.NumTicks))
+		out = append(out, byte(b.Side))
+		// Reserved flag/count bytes survive; the spare byte goes out zero.
+		return append(out, b.Padding[0], b.Padding[1], b.Padding[2], b.Padding[3], 0), nil
+	case HeartbeatBody:
+		out := goldenU32(b.Sequence)
+		out = append(out, b.Timestamp[:]...)
+		return append(out, 0, 0), nil // padding carries no flags
+	case BookChecksumBody:
+		out := goldenU64(b.TickerID)
+		out = append(out, goldenU32(b.Checksum)...)
+		return append(out, 0, 0, 0, 0), nil // padding carries no flags
+	}
+	return nil, ErrUnknownMessageType
+}
+
+// goldenU64 encodes a uint64 big-endian with explicit shifts.
+func goldenU64(v uint64) []byte {
+	return []byte{
+		byte(v >> 56), byte(v >> 48), byte(v >> 40), byte(v >> 32),
+		byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v),
+	}
+}
+
+// goldenU32 encodes a uint32 big-endian with explicit shifts.
+func goldenU32(v uint32) []byte {
+	return []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+// TestGoldenBytesAgainstMarshal cross-checks every encoder against the
+// independent golden layout at adversarial field values (high bits set in
+// every byte position, negative and fractional floats).
+func TestGoldenBytesAgainstMarshal(t *testing.T) {
+	bodies := []interface{ MarshalBinary() ([]byte, error) }{
+		TradeBody{TickerID: 0xFFEEDDCCBBAA9988, Price: -123.456, Quantity: 0x80000001, TradeID: 0x7FFFFFFF, Side: SideSell, Padding: [7]byte{1, 2, 3, 4, 5, 6, 7}},
+		OrderBody{TickerID: 1, OrderID: 0xFFFFFFFF, Price: math.MaxFloat64, Quantity: 2, TypeAndSide: OrderTypeCancel<<1 | SideBuy, Expiry: [6]byte{9, 8, 7, 6, 5, 4}},
+		TickerBody{TickerID: 2, BidPrice: 0.1, AskPrice: -0.1, BidVolume: 3, AskVolume: 4},
+		OrderBookBody{TickerID: 3, FirstTick: 1e-9, TickSize: 1e9, NumTicks: 0xBEEF, Side: SideBuy, Padding: [5]byte{5, 4, 3, 2, 1}},
+		HeartbeatBody{Sequence: 0xCAFEBABE, Timestamp: [6]byte{1, 2, 3, 4, 5, 6}},
+		BookChecksumBody{TickerID: 4, Checksum: 0x89ABCDEF},
+	}
+	for _, body := range bodies {
+		want, err := GoldenBytes(body)
+		if err != nil {
+			t.Fatalf("%T: GoldenBytes: %v", body, err)
+		}
+		got, err := body.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%T: MarshalBinary: %v", body, err)
+		}
+		if hex.EncodeToString(got) != hex.EncodeToString(want) {
+			t.Errorf("%T layout drifted:\ngot  %x\nwant %x", body, got, want)
+		}
+	}
+}
+
+func TestGoldenBytesUnknownBody(t *testing.T) {
+	if _, err := GoldenBytes(struct{}{}); err == nil {
+		t.Error("unknown body type must error")
+	}
+}
@@ -0,0 +1,74 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHeaderVersionDispatch(t *testing.T) {
+	base := MitchHeader{
+		MessageType: MsgTypeTrade,
+		Timestamp:   WriteTimestamp48(123456789),
+		Count:       3,
+	}
+
+	for _, version := range []HeaderVersion{HeaderV1, HeaderV2} {
+		packed, err := PackHeaderVersioned(VersionedHeader{
+			MitchHeader: base,
+			Version:     version,
+			Sequence:    0xCAFE0001,
+		})
+		if err != nil {
+			t.Fatalf("v%d pack: %v", version, err)
+		}
+		wantSize, _ := HeaderSizeFor(version)
+		if len(packed) != wantSize {
+			t.Errorf("v%d packed %d bytes, want %d", version, len(packed), wantSize)
+		}
+
+		header, size, err := UnpackHeaderVersioned(version, packed)
+		if err != nil {
+			t.Fatalf("v%d unpack: %v", version, err)
+		}
+		if size != wantSize || header.MitchHeader != base {
+			t.Errorf("v%d round trip = %+v size %d", version, header, size)
+		}
+		switch version {
+		case HeaderV1:
+			if header.Sequence != 0 {
+				t.Errorf("v1 must not carry a sequence, got %d", header.Sequence)
+			}
+		case HeaderV2:
+			if header.Sequence != 0xCAFE0001 {
+				t.Errorf("v2 sequence = %08X, want CAFE0001", header.Sequence)
+			}
+		}
+	}
+}
+
+func TestHeaderVersionedV1CompatibleWithUnpackHeader(t *testing.T) {
+	// A v1 versioned header must be byte-identical to the spec header.
+	frame, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, size, err := UnpackHeaderVersioned(HeaderV1, frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != HeaderSize || header.MessageType != MsgTypeTicker || header.Count != 1 {
+		t.Errorf("v1 parse = %+v size %d", header, size)
+	}
+}
+
+func TestHeaderVersionErrors(t *testing.T) {
+	if _, _, err := UnpackHeaderVersioned(HeaderVersion(9), make([]byte, 16)); err == nil {
+		t.Error("unknown version must error")
+	}
+	if _, err := PackHeaderVersioned(VersionedHeader{Version: 0}); err == nil {
+		t.Error("unknown version must error on pack")
+	}
+	if _, _, err := UnpackHeaderVersioned(HeaderV2, make([]byte, HeaderSizeV1)); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("short v2 err = %v, want ErrShortBuffer", err)
+	}
+}
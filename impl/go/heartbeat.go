@@ -0,0 +1,79 @@
+// MITCH heartbeat messages for keepalive.
+//
+// Long-lived TCP feeds go silent during quiet markets; periodic heartbeats
+// let a consumer tell a dead socket from an idle one. The body is a small
+// fixed 12-byte record outside the 32-byte family.
+
+package mitch
+
+import (
+	"fmt"
+	"time"
+)
+
+// MsgTypeHeartbeat is the message type code for heartbeats.
+const MsgTypeHeartbeat MessageType = 'h'
+
+// HeartbeatBodySize is the heartbeat body size in bytes.
+const HeartbeatBodySize = 12
+
+// HeartbeatBody is a keepalive record (12 bytes).
+type HeartbeatBody struct {
+	Sequence  uint32  // monotonically increasing per sender
+	Timestamp [6]byte // u48: sender's nanoseconds since midnight UTC
+	Padding   [2]byte // padding to 12 bytes
+}
+
+// MarshalBinary encodes the heartbeat into its 12-byte wire layout.
+func (h HeartbeatBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, HeartbeatBodySize)
+	ByteOrder.PutUint32(data[0:4], h.Sequence)
+	copy(data[4:10], h.Timestamp[:])
+	// data[10:12] stays zero: heartbeat padding carries no flags.
+	return data, nil
+}
+
+// UnmarshalBinary decodes the heartbeat from its 12-byte wire layout.
+func (h *HeartbeatBody) UnmarshalBinary(data []byte) error {
+	if len(data) < HeartbeatBodySize {
+		return fmt.Errorf("mitch: %w: heartbeat body is %d bytes (need %d)", ErrShortBuffer, len(data), HeartbeatBodySize)
+	}
+	h.Sequence = ByteOrder.Uint32(data[0:4])
+	copy(h.Timestamp[:], data[4:10])
+	copy(h.Padding[:], data[10:12])
+	return nil
+}
+
+// PackHeartbeat packs a single heartbeat carrying the given sequence number
+// and the sender's current 48-bit timestamp.
+func PackHeartbeat(seq uint32) ([]byte, error) {
+	now := WriteTimestamp48(nanosSinceMidnight(time.Now()))
+	header := MitchHeader{
+		MessageType: MsgTypeHeartbeat,
+		Timestamp:   now,
+		Count:       1,
+	}
+	body := HeartbeatBody{Sequence: seq, Timestamp: now}
+
+	data := make([]byte, HeaderSize+HeartbeatBodySize)
+	packHeaderInto(data, header)
+	encoded, _ := body.MarshalBinary()
+	copy(data[HeaderSize:], encoded)
+	return data, nil
+}
+
+// UnpackHeartbeat deserializes a heartbeat message.
+func UnpackHeartbeat(data []byte) (*MitchHeader, *HeartbeatBody, error) {
+	if len(data) < HeaderSize+HeartbeatBodySize {
+		return nil, nil, fmt.Errorf("mitch: %w: heartbeat message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize+HeartbeatBodySize)
+	}
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeHeartbeat {
+		return nil, nil, fmt.Errorf("mitch: message type %q is not a heartbeat", header.MessageType)
+	}
+	body := new(HeartbeatBody)
+	if err := body.UnmarshalBinary(data[HeaderSize:]); err != nil {
+		return nil, nil, err
+	}
+	return header, body, nil
+}
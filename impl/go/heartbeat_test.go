@@ -0,0 +1,51 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHeartbeatRoundTrip(t *testing.T) {
+	data, err := PackHeartbeat(42)
+	if err != nil {
+		t.Fatalf("PackHeartbeat failed: %v", err)
+	}
+	if len(data) != HeaderSize+HeartbeatBodySize {
+		t.Errorf("packed size = %d, want %d", len(data), HeaderSize+HeartbeatBodySize)
+	}
+	header, body, err := UnpackHeartbeat(data)
+	if err != nil {
+		t.Fatalf("UnpackHeartbeat failed: %v", err)
+	}
+	if header.MessageType != MsgTypeHeartbeat || header.Count != 1 {
+		t.Errorf("header = %+v", header)
+	}
+	if body.Sequence != 42 {
+		t.Errorf("sequence = %d, want 42", body.Sequence)
+	}
+	if body.Timestamp != header.Timestamp {
+		t.Errorf("body timestamp %v differs from header %v", body.Timestamp, header.Timestamp)
+	}
+}
+
+func TestDecoderSurfacesHeartbeat(t *testing.T) {
+	data, err := PackHeartbeat(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDecoder(bytes.NewReader(data))
+	header, bodies, err := d.Decode()
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if header.MessageType != MsgTypeHeartbeat {
+		t.Fatalf("message type = %q, want %q", header.MessageType, MsgTypeHeartbeat)
+	}
+	hb, ok := bodies[0].(*HeartbeatBody)
+	if !ok {
+		t.Fatalf("body type = %T, want *HeartbeatBody", bodies[0])
+	}
+	if hb.Sequence != 7 {
+		t.Errorf("sequence = %d, want 7", hb.Sequence)
+	}
+}
@@ -0,0 +1,88 @@
+// Per-instrument price metadata and normalization.
+//
+// Instruments quote at wildly different magnitudes — a JPY pair ticks in
+// hundredths while some crypto pairs tick in billionths — so "round to a
+// valid price" needs per-instrument metadata. InstrumentRegistry holds
+// that metadata keyed by TickerID and snaps float prices onto the
+// instrument's tick grid; the Decoder can consult it to normalize decoded
+// prices in place.
+
+package mitch
+
+import (
+	"math"
+	"sync"
+)
+
+// InstrumentMeta describes one instrument's price grid.
+type InstrumentMeta struct {
+	Precision int     // decimal places quoted, informational
+	TickSize  float64 // price increment; 0 disables normalization
+}
+
+// InstrumentRegistry maps TickerIDs to their price metadata. Safe for
+// concurrent use.
+type InstrumentRegistry struct {
+	mu    sync.RWMutex
+	metas map[uint64]InstrumentMeta
+}
+
+// NewInstrumentRegistry returns an empty registry.
+func NewInstrumentRegistry() *InstrumentRegistry {
+	return &InstrumentRegistry{metas: make(map[uint64]InstrumentMeta)}
+}
+
+// Register stores the metadata for an instrument, replacing any previous
+// entry.
+func (r *InstrumentRegistry) Register(id uint64, meta InstrumentMeta) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metas[id] = meta
+}
+
+// Lookup returns the metadata for an instrument.
+func (r *InstrumentRegistry) Lookup(id uint64) (InstrumentMeta, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	meta, ok := r.metas[id]
+	return meta, ok
+}
+
+// NormalizePrice rounds price to the instrument's nearest valid tick. For
+// unknown instruments or a zero tick size it returns the price unchanged.
+func (r *InstrumentRegistry) NormalizePrice(id uint64, price float64) float64 {
+	meta, ok := r.Lookup(id)
+	if !ok || meta.TickSize <= 0 {
+		return price
+	}
+	return math.Round(price/meta.TickSize) * meta.TickSize
+}
+
+// aligned reports whether price already sits on the instrument's tick
+// grid, within one part in 1e9 of a tick to absorb float64 noise.
+func (r *InstrumentRegistry) aligned(id uint64, price float64) bool {
+	meta, ok := r.Lookup(id)
+	if !ok || meta.TickSize <= 0 {
+		return true
+	}
+	offset := math.Abs(price - math.Round(price/meta.TickSize)*meta.TickSize)
+	return offset <= meta.TickSize*1e-9
+}
+
+// normalizeBody snaps a decoded body's prices onto its instrument's grid,
+// reporting whether any price was off-grid before normalization.
+func (r *InstrumentRegistry) normalizeBody(body interface{}) (misaligned bool) {
+	switch b := body.(type) {
+	case *TradeBody:
+		misaligned = !r.aligned(b.TickerID, b.Price)
+		b.Price = r.NormalizePrice(b.TickerID, b.Price)
+	case *OrderBody:
+		misaligned = !r.aligned(b.TickerID, b.Price)
+		b.Price = r.NormalizePrice(b.TickerID, b.Price)
+	case *TickerBody:
+		misaligned = !r.aligned(b.TickerID, b.BidPrice) || !r.aligned(b.TickerID, b.AskPrice)
+		b.BidPrice = r.NormalizePrice(b.TickerID, b.BidPrice)
+		b.AskPrice = r.NormalizePrice(b.TickerID, b.AskPrice)
+	}
+	return misaligned
+}
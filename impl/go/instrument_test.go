@@ -0,0 +1,57 @@
+package mitch
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestNormalizePrice(t *testing.T) {
+	r := NewInstrumentRegistry()
+	r.Register(1, InstrumentMeta{Precision: 2, TickSize: 0.25})
+
+	tests := []struct {
+		id    uint64
+		price float64
+		want  float64
+	}{
+		{1, 100.25, 100.25},   // already on grid
+		{1, 100.30, 100.25},   // snaps down
+		{1, 100.40, 100.50},   // snaps up
+		{2, 123.456, 123.456}, // unknown instrument passes through
+	}
+	for _, tt := range tests {
+		if got := r.NormalizePrice(tt.id, tt.price); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("NormalizePrice(%d, %v) = %v, want %v", tt.id, tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestDecoderNormalize(t *testing.T) {
+	instruments := NewInstrumentRegistry()
+	instruments.Register(1, InstrumentMeta{Precision: 2, TickSize: 0.5})
+
+	frame, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 100.5}, // aligned
+		TradeBody{TickerID: 1, Price: 100.7}, // off grid
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := NewDecoder(bytes.NewReader(frame))
+	decoder.Normalize(instruments)
+	_, bodies, err := decoder.Decode()
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got := bodies[0].(*TradeBody).Price; got != 100.5 {
+		t.Errorf("aligned price changed to %v", got)
+	}
+	if got := bodies[1].(*TradeBody).Price; math.Abs(got-100.5) > 1e-12 {
+		t.Errorf("off-grid price = %v, want 100.5", got)
+	}
+	if decoder.Misaligned() != 1 {
+		t.Errorf("Misaligned = %d, want 1", decoder.Misaligned())
+	}
+}
@@ -0,0 +1,134 @@
+// JSON marshaling for debugging and log pipelines.
+//
+// Output is one stable, lowercase-keyed object per type: the 48-bit
+// timestamp is expanded to an RFC3339 nanosecond string (on the Unix epoch
+// date, since the wire timestamp carries no day), and TypeAndSide is split
+// into separate "side" and "orderType" strings. These types do not
+// implement UnmarshalJSON; the binary format remains the interchange
+// format.
+
+package mitch
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// jsonTime renders a 48-bit timestamp as an RFC3339 nanosecond string on
+// the epoch date.
+func jsonTime(ts [6]byte) string {
+	return TimestampToTime(ts, time.Unix(0, 0).UTC()).Format(time.RFC3339Nano)
+}
+
+// sideString renders a side byte as "buy", "sell" or the raw number.
+func sideString(side uint8) string {
+	switch side {
+	case SideBuy:
+		return "buy"
+	case SideSell:
+		return "sell"
+	}
+	return "unknown"
+}
+
+// orderTypeString renders an order type as its constant name.
+func orderTypeString(orderType uint8) string {
+	switch orderType {
+	case OrderTypeMarket:
+		return "market"
+	case OrderTypeLimit:
+		return "limit"
+	case OrderTypeStop:
+		return "stop"
+	case OrderTypeCancel:
+		return "cancel"
+	case OrderTypeModify:
+		return "modify"
+	}
+	return "unknown"
+}
+
+// MarshalJSON emits the header with the message type as a character and the
+// timestamp expanded to RFC3339.
+func (h MitchHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		MessageType string `json:"messageType"`
+		Timestamp   string `json:"timestamp"`
+		Count       uint8  `json:"count"`
+	}{string(rune(byte(h.MessageType))), jsonTime(h.Timestamp), h.Count})
+}
+
+// MarshalJSON emits the trade with a decoded side string.
+func (t TradeBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TickerID uint64  `json:"tickerId"`
+		Price    float64 `json:"price"`
+		Quantity uint32  `json:"quantity"`
+		TradeID  uint32  `json:"tradeId"`
+		Side     string  `json:"side"`
+	}{t.TickerID, t.Price, t.Quantity, t.TradeID, sideString(t.Side)})
+}
+
+// MarshalJSON emits the order with TypeAndSide split into separate side and
+// orderType strings and the expiry expanded to RFC3339 (empty for GTC).
+func (o OrderBody) MarshalJSON() ([]byte, error) {
+	expiry := ""
+	if o.Expiry != ([6]byte{}) {
+		expiry = jsonTime(o.Expiry)
+	}
+	return json.Marshal(struct {
+		TickerID  uint64  `json:"tickerId"`
+		OrderID   uint32  `json:"orderId"`
+		Price     float64 `json:"price"`
+		Quantity  uint32  `json:"quantity"`
+		OrderType string  `json:"orderType"`
+		Side      string  `json:"side"`
+		Expiry    string  `json:"expiry,omitempty"`
+	}{o.TickerID, o.OrderID, o.Price, o.Quantity,
+		orderTypeString(o.TypeAndSide >> 1), sideString(o.TypeAndSide & 0x01), expiry})
+}
+
+// MarshalJSON emits the ticker fields.
+func (t TickerBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TickerID  uint64  `json:"tickerId"`
+		BidPrice  float64 `json:"bidPrice"`
+		AskPrice  float64 `json:"askPrice"`
+		BidVolume uint32  `json:"bidVolume"`
+		AskVolume uint32  `json:"askVolume"`
+	}{t.TickerID, t.BidPrice, t.AskPrice, t.BidVolume, t.AskVolume})
+}
+
+// MarshalJSON emits the book header with a decoded side string.
+func (b OrderBookBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		TickerID  uint64  `json:"tickerId"`
+		FirstTick float64 `json:"firstTick"`
+		TickSize  float64 `json:"tickSize"`
+		NumTicks  uint16  `json:"numTicks"`
+		Side      string  `json:"side"`
+	}{b.TickerID, b.FirstTick, b.TickSize, b.NumTicks, bookSideString(b.Side)})
+}
+
+// MarshalJSON emits the book header together with its volumes array.
+func (e OrderBookEntry) MarshalJSON() ([]byte, error) {
+	volumes := e.Volumes
+	if volumes == nil {
+		volumes = []uint32{}
+	}
+	return json.Marshal(struct {
+		Book    *OrderBookBody `json:"book"`
+		Volumes []uint32       `json:"volumes"`
+	}{e.Body, volumes})
+}
+
+// bookSideString renders a book side byte as "bids" or "asks".
+func bookSideString(side Side) string {
+	switch side {
+	case BookSideBid:
+		return "bids"
+	case BookSideAsk:
+		return "asks"
+	}
+	return "unknown"
+}
@@ -0,0 +1,71 @@
+package mitch
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTradeBodyJSON(t *testing.T) {
+	trade := TradeBody{TickerID: 7, Price: 1.5, Quantity: 100, TradeID: 9, Side: SideSell}
+	out, err := json.Marshal(trade)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `{"tickerId":7,"price":1.5,"quantity":100,"tradeId":9,"side":"sell"}`
+	if string(out) != want {
+		t.Errorf("json = %s, want %s", out, want)
+	}
+}
+
+func TestOrderBodyJSONSplitsTypeAndSide(t *testing.T) {
+	order := OrderBody{
+		TickerID:    1,
+		OrderID:     2,
+		Price:       10,
+		Quantity:    3,
+		TypeAndSide: OrderTypeLimit<<1 | SideBuy,
+	}
+	out, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	s := string(out)
+	if !strings.Contains(s, `"orderType":"limit"`) || !strings.Contains(s, `"side":"buy"`) {
+		t.Errorf("json missing decoded type/side: %s", s)
+	}
+	if strings.Contains(s, `"expiry"`) {
+		t.Errorf("GTC order should omit expiry: %s", s)
+	}
+}
+
+func TestHeaderJSONTimestamp(t *testing.T) {
+	header := MitchHeader{
+		MessageType: MsgTypeTicker,
+		Timestamp:   WriteTimestamp48(34200*1e9 + 1), // 09:30:00.000000001
+		Count:       1,
+	}
+	out, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `{"messageType":"s","timestamp":"1970-01-01T09:30:00.000000001Z","count":1}`
+	if string(out) != want {
+		t.Errorf("json = %s, want %s", out, want)
+	}
+}
+
+func TestOrderBookEntryJSONIncludesVolumes(t *testing.T) {
+	entry := OrderBookEntry{
+		Body:    &OrderBookBody{TickerID: 5, FirstTick: 100, TickSize: 0.5, NumTicks: 3, Side: 1},
+		Volumes: []uint32{4, 5, 6},
+	}
+	out, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	s := string(out)
+	if !strings.Contains(s, `"volumes":[4,5,6]`) || !strings.Contains(s, `"side":"asks"`) {
+		t.Errorf("json = %s", s)
+	}
+}
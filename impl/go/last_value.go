@@ -0,0 +1,80 @@
+// Last-value cache for late-joining consumers.
+//
+// A consumer that connects mid-session has nothing until each instrument
+// next prints; serving the latest known state immediately needs someone
+// to remember it. LastValueCache ingests decoded messages and keeps the
+// most recent ticker and trade per TickerID behind an RWMutex, so one
+// writer can update from the feed while many readers answer snapshot
+// requests.
+
+package mitch
+
+import "sync"
+
+// LastValueCache stores the most recent TickerBody and TradeBody per
+// instrument. Safe for one concurrent writer and many readers.
+type LastValueCache struct {
+	mu      sync.RWMutex
+	tickers map[uint64]TickerBody
+	trades  map[uint64]TradeBody
+}
+
+// NewLastValueCache returns an empty cache.
+func NewLastValueCache() *LastValueCache {
+	return &LastValueCache{
+		tickers: make(map[uint64]TickerBody),
+		trades:  make(map[uint64]TradeBody),
+	}
+}
+
+// Ingest updates the cache from one decoded message's bodies. Ticker and
+// trade bodies replace the stored value for their instrument; other body
+// types are ignored.
+func (c *LastValueCache) Ingest(bodies []interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, body := range bodies {
+		switch v := body.(type) {
+		case *TickerBody:
+			c.tickers[v.TickerID] = *v
+		case TickerBody:
+			c.tickers[v.TickerID] = v
+		case *TradeBody:
+			c.trades[v.TickerID] = *v
+		case TradeBody:
+			c.trades[v.TickerID] = v
+		}
+	}
+}
+
+// GetTicker returns the most recent ticker for the instrument, reporting
+// whether one has been seen.
+func (c *LastValueCache) GetTicker(id uint64) (TickerBody, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ticker, ok := c.tickers[id]
+	return ticker, ok
+}
+
+// GetTrade returns the most recent trade for the instrument, reporting
+// whether one has been seen.
+func (c *LastValueCache) GetTrade(id uint64) (TradeBody, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	trade, ok := c.trades[id]
+	return trade, ok
+}
+
+// Len returns the number of tracked instruments: those with at least one
+// cached ticker or trade.
+func (c *LastValueCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	n := len(c.tickers)
+	for id := range c.trades {
+		if _, ok := c.tickers[id]; !ok {
+			n++
+		}
+	}
+	return n
+}
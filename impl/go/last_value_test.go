@@ -0,0 +1,84 @@
+package mitch
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLastValueCache(t *testing.T) {
+	cache := NewLastValueCache()
+
+	if _, ok := cache.GetTicker(1); ok {
+		t.Error("empty cache returned a ticker")
+	}
+
+	cache.Ingest([]interface{}{
+		&TickerBody{TickerID: 1, BidPrice: 10, AskPrice: 11},
+		&TradeBody{TickerID: 1, Price: 10.5, TradeID: 100},
+		&TickerBody{TickerID: 2, BidPrice: 20, AskPrice: 21},
+	})
+	// A newer ticker replaces the old one.
+	cache.Ingest([]interface{}{
+		TickerBody{TickerID: 1, BidPrice: 12, AskPrice: 13},
+	})
+
+	ticker, ok := cache.GetTicker(1)
+	if !ok || ticker.BidPrice != 12 {
+		t.Errorf("GetTicker(1) = %+v, %v; want latest bid 12", ticker, ok)
+	}
+	trade, ok := cache.GetTrade(1)
+	if !ok || trade.TradeID != 100 {
+		t.Errorf("GetTrade(1) = %+v, %v", trade, ok)
+	}
+	if _, ok := cache.GetTrade(2); ok {
+		t.Error("GetTrade(2) returned a trade never ingested")
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	// Trade-only instruments still count once.
+	cache.Ingest([]interface{}{&TradeBody{TickerID: 3, TradeID: 7}})
+	if got := cache.Len(); got != 3 {
+		t.Errorf("Len() after trade-only instrument = %d, want 3", got)
+	}
+}
+
+func TestLastValueCacheConcurrentReaders(t *testing.T) {
+	cache := NewLastValueCache()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := uint32(0); i < 1000; i++ {
+			cache.Ingest([]interface{}{
+				&TickerBody{TickerID: 1, BidVolume: i},
+				&TradeBody{TickerID: 1, TradeID: i},
+			})
+		}
+		close(done)
+	}()
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				cache.GetTicker(1)
+				cache.GetTrade(1)
+				cache.Len()
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if trade, ok := cache.GetTrade(1); !ok || trade.TradeID != 999 {
+		t.Errorf("final trade = %+v, %v; want TradeID 999", trade, ok)
+	}
+}
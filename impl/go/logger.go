@@ -0,0 +1,37 @@
+// Pluggable logging for the transport helpers.
+//
+// The Client, Server and Decoder are deliberately silent — a reconnect or
+// a dropped consumer just happens, which makes production incidents hard
+// to reconstruct. A two-method Logger keeps it that way by default (the
+// no-op logger costs one nil-check per event) while letting integrators
+// plug in zap, slog or anything printf-shaped without this package
+// importing any logging dependency.
+
+package mitch
+
+// Logger receives transport diagnostics: Debugf for routine lifecycle
+// events (reconnects, skipped messages), Errorf for failures (decode
+// errors, dropped consumers). Implementations must be safe for concurrent
+// use; both slog and zap sugared loggers satisfy it via thin adapters.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// nopLogger discards everything; it is the default when no logger is set.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...interface{}) {}
+func (nopLogger) Errorf(string, ...interface{}) {}
+
+// NopLogger is a Logger that discards all output.
+var NopLogger Logger = nopLogger{}
+
+// orNop substitutes the no-op logger for nil so call sites never
+// nil-check.
+func orNop(l Logger) Logger {
+	if l == nil {
+		return NopLogger
+	}
+	return l
+}
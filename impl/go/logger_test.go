@@ -0,0 +1,122 @@
+package mitch
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordLogger captures formatted log lines for assertions.
+type recordLogger struct {
+	mu     sync.Mutex
+	debugs []string
+	errors []string
+}
+
+func (r *recordLogger) Debugf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.debugs = append(r.debugs, fmt.Sprintf(format, args...))
+}
+
+func (r *recordLogger) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestDecoderLogsErrors(t *testing.T) {
+	var log recordLogger
+	truncated := []byte{byte(MsgTypeTrade), 0, 0, 0, 0, 0, 0, 1, 0xAA}
+	d := NewDecoder(bytes.NewReader(truncated))
+	d.SetLogger(&log)
+
+	if _, _, err := d.Decode(); err == nil {
+		t.Fatal("truncated message decoded")
+	}
+	if len(log.errors) != 1 || !strings.Contains(log.errors[0], "decode") {
+		t.Errorf("error log = %q, want one decode error", log.errors)
+	}
+}
+
+func TestDecoderDoesNotLogCleanEOF(t *testing.T) {
+	var log recordLogger
+	d := NewDecoder(bytes.NewReader(nil))
+	d.SetLogger(&log)
+	d.Decode()
+	if len(log.errors) != 0 {
+		t.Errorf("clean EOF logged as error: %q", log.errors)
+	}
+}
+
+func TestDecoderLogsSkippedMessages(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write([]byte{0x5A, 0, 0, 0, 0, 0, 0, 0}) // unknown type, zero bodies
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Write(message)
+
+	var log recordLogger
+	d := NewDecoder(&stream)
+	d.SkipUnknown(true)
+	d.SetLogger(&log)
+	if _, _, err := d.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.debugs) != 1 || !strings.Contains(log.debugs[0], "0x5A") {
+		t.Errorf("debug log = %q, want one skipped-message line", log.debugs)
+	}
+}
+
+func TestClientLogsReconnect(t *testing.T) {
+	a1, b1 := NewChanConnPair()
+	a2, b2 := NewChanConnPair()
+	conns := []*ChanConn{a1, a2}
+	dials := 0
+
+	c := NewClient()
+	c.minBackoff = time.Millisecond
+	c.dialFunc = func(addr string) (net.Conn, error) {
+		conn := conns[dials]
+		dials++
+		return conn, nil
+	}
+	var log recordLogger
+	c.SetLogger(&log)
+	if err := c.Dial("test"); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	defer b2.Close()
+
+	// Kill the first connection to force a reconnect.
+	b1.Close()
+	for m := range c.Messages() {
+		if m.Reconnected {
+			break
+		}
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	lost, reconnected := false, false
+	for _, line := range log.errors {
+		if strings.Contains(line, "lost") {
+			lost = true
+		}
+	}
+	for _, line := range log.debugs {
+		if strings.Contains(line, "reconnected") {
+			reconnected = true
+		}
+	}
+	if !lost || !reconnected {
+		t.Errorf("logs missing lifecycle events: errors=%q debugs=%q", log.errors, log.debugs)
+	}
+}
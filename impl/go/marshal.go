@@ -0,0 +1,109 @@
+// Hand-rolled binary marshaling for the fixed 32-byte body types.
+//
+// Each method encodes field by field in big-endian order into the spec's
+// exact byte layout, independent of Go struct field ordering, and satisfies
+// encoding.BinaryMarshaler / encoding.BinaryUnmarshaler.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+)
+
+// MarshalBinary encodes the trade into its 32-byte wire layout.
+func (t TradeBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, BodySizeFixed)
+	ByteOrder.PutUint64(data[0:8], t.TickerID)
+	ByteOrder.PutUint64(data[8:16], math.Float64bits(t.Price))
+	ByteOrder.PutUint32(data[16:20], t.Quantity)
+	ByteOrder.PutUint32(data[20:24], t.TradeID)
+	data[24] = t.Side
+	pad := sanitizedTradePadding(t.Padding)
+	copy(data[25:32], pad[:])
+	return data, nil
+}
+
+// UnmarshalBinary decodes the trade from its 32-byte wire layout.
+func (t *TradeBody) UnmarshalBinary(data []byte) error {
+	if len(data) < BodySizeFixed {
+		return fmt.Errorf("mitch: %w: trade body is %d bytes (need %d)", ErrShortBuffer, len(data), BodySizeFixed)
+	}
+	t.TickerID = ByteOrder.Uint64(data[0:8])
+	t.Price = math.Float64frombits(ByteOrder.Uint64(data[8:16]))
+	t.Quantity = ByteOrder.Uint32(data[16:20])
+	t.TradeID = ByteOrder.Uint32(data[20:24])
+	t.Side = data[24]
+	copy(t.Padding[:], data[25:32])
+	return nil
+}
+
+// MarshalBinary encodes the order into its 32-byte wire layout.
+func (o OrderBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, BodySizeFixed)
+	ByteOrder.PutUint64(data[0:8], o.TickerID)
+	ByteOrder.PutUint32(data[8:12], o.OrderID)
+	ByteOrder.PutUint64(data[12:20], math.Float64bits(o.Price))
+	ByteOrder.PutUint32(data[20:24], o.Quantity)
+	data[24] = o.TypeAndSide
+	copy(data[25:31], o.Expiry[:])
+	// data[31] stays zero: order padding carries no flags.
+	return data, nil
+}
+
+// UnmarshalBinary decodes the order from its 32-byte wire layout.
+func (o *OrderBody) UnmarshalBinary(data []byte) error {
+	if len(data) < BodySizeFixed {
+		return fmt.Errorf("mitch: %w: order body is %d bytes (need %d)", ErrShortBuffer, len(data), BodySizeFixed)
+	}
+	o.TickerID = ByteOrder.Uint64(data[0:8])
+	o.OrderID = ByteOrder.Uint32(data[8:12])
+	o.Price = math.Float64frombits(ByteOrder.Uint64(data[12:20]))
+	o.Quantity = ByteOrder.Uint32(data[20:24])
+	o.TypeAndSide = data[24]
+	copy(o.Expiry[:], data[25:31])
+	copy(o.Padding[:], data[31:32])
+	return nil
+}
+
+// MarshalBinary encodes the ticker into its 32-byte wire layout.
+func (t TickerBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, BodySizeFixed)
+	ByteOrder.PutUint64(data[0:8], t.TickerID)
+	ByteOrder.PutUint64(data[8:16], math.Float64bits(t.BidPrice))
+	ByteOrder.PutUint64(data[16:24], math.Float64bits(t.AskPrice))
+	ByteOrder.PutUint32(data[24:28], t.BidVolume)
+	ByteOrder.PutUint32(data[28:32], t.AskVolume)
+	return data, nil
+}
+
+// UnmarshalBinary decodes the ticker from its 32-byte wire layout.
+func (t *TickerBody) UnmarshalBinary(data []byte) error {
+	if len(data) < BodySizeFixed {
+		return fmt.Errorf("mitch: %w: ticker body is %d bytes (need %d)", ErrShortBuffer, len(data), BodySizeFixed)
+	}
+	t.TickerID = ByteOrder.Uint64(data[0:8])
+	t.BidPrice = math.Float64frombits(ByteOrder.Uint64(data[8:16]))
+	t.AskPrice = math.Float64frombits(ByteOrder.Uint64(data[16:24]))
+	t.BidVolume = ByteOrder.Uint32(data[24:28])
+	t.AskVolume = ByteOrder.Uint32(data[28:32])
+	return nil
+}
+
+// MarshalBinary encodes the fixed 32-byte book header. The volume array is
+// separate; see PackOrderBook for the complete message.
+func (b OrderBookBody) MarshalBinary() ([]byte, error) {
+	data := make([]byte, BodySizeFixed)
+	packOrderBookBodyInto(data, b)
+	return data, nil
+}
+
+// UnmarshalBinary decodes the fixed 32-byte book header. The volume array
+// is separate; see UnpackOrderBook for the complete message.
+func (b *OrderBookBody) UnmarshalBinary(data []byte) error {
+	if len(data) < BodySizeFixed {
+		return fmt.Errorf("mitch: %w: order book body is %d bytes (need %d)", ErrShortBuffer, len(data), BodySizeFixed)
+	}
+	*b = *unpackOrderBookBody(data)
+	return nil
+}
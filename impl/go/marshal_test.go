@@ -0,0 +1,134 @@
+package mitch
+
+import (
+	"encoding"
+	"testing"
+)
+
+// The body types must satisfy the standard binary marshaling interfaces.
+var (
+	_ encoding.BinaryMarshaler   = TradeBody{}
+	_ encoding.BinaryUnmarshaler = (*TradeBody)(nil)
+	_ encoding.BinaryMarshaler   = OrderBody{}
+	_ encoding.BinaryUnmarshaler = (*OrderBody)(nil)
+	_ encoding.BinaryMarshaler   = TickerBody{}
+	_ encoding.BinaryUnmarshaler = (*TickerBody)(nil)
+	_ encoding.BinaryMarshaler   = OrderBookBody{}
+	_ encoding.BinaryUnmarshaler = (*OrderBookBody)(nil)
+)
+
+func TestTradeBodyBinaryRoundTrip(t *testing.T) {
+	in := TradeBody{
+		TickerID: 0x1122334455667788,
+		Price:    1234.5678,
+		Quantity: 1000,
+		TradeID:  42,
+		Side:     SideSell,
+		Padding:  [7]byte{1, 2, 3, 4, 5, 6, 7},
+	}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if len(data) != BodySizeFixed {
+		t.Fatalf("encoded size = %d, want %d", len(data), BodySizeFixed)
+	}
+	var out TradeBody
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	// Padding is sanitized on the wire: only the synthetic flag survives.
+	want := in
+	want.Padding = [7]byte{in.Padding[0] & 1}
+	if out != want {
+		t.Errorf("round trip = %+v, want %+v", out, want)
+	}
+}
+
+func TestOrderBodyBinaryRoundTrip(t *testing.T) {
+	in := OrderBody{
+		TickerID:    7,
+		OrderID:     99,
+		Price:       0.00012345,
+		Quantity:    500,
+		TypeAndSide: OrderTypeLimit<<1 | SideBuy,
+		Expiry:      [6]byte{0xAA, 0xBB, 0xCC, 0xDD, 0xEE, 0xFF},
+		Padding:     [1]byte{0x55},
+	}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	var out OrderBody
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	// Order padding carries no flags and is zeroed on the wire.
+	want := in
+	want.Padding = [1]byte{}
+	if out != want {
+		t.Errorf("round trip = %+v, want %+v", out, want)
+	}
+}
+
+func TestTickerBodyBinaryRoundTrip(t *testing.T) {
+	in := TickerBody{
+		TickerID:  3,
+		BidPrice:  1.08551,
+		AskPrice:  1.08553,
+		BidVolume: 1_000_000,
+		AskVolume: 750_000,
+	}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	var out TickerBody
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestOrderBookBodyBinaryRoundTrip(t *testing.T) {
+	in := OrderBookBody{
+		TickerID:  11,
+		FirstTick: 50000,
+		TickSize:  0.5,
+		NumTicks:  128,
+		Side:      SideSell,
+		Padding:   [5]byte{9, 8, 7, 6, 5},
+	}
+	data, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	var out OrderBookBody
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	// Reserved padding bytes survive; the spare fifth is zeroed.
+	want := in
+	want.Padding[4] = 0
+	if out != want {
+		t.Errorf("round trip = %+v, want %+v", out, want)
+	}
+}
+
+func TestUnmarshalBinaryShortBuffer(t *testing.T) {
+	short := make([]byte, BodySizeFixed-1)
+	if err := (&TradeBody{}).UnmarshalBinary(short); err == nil {
+		t.Error("TradeBody: expected error for short buffer")
+	}
+	if err := (&OrderBody{}).UnmarshalBinary(short); err == nil {
+		t.Error("OrderBody: expected error for short buffer")
+	}
+	if err := (&TickerBody{}).UnmarshalBinary(short); err == nil {
+		t.Error("TickerBody: expected error for short buffer")
+	}
+	if err := (&OrderBookBody{}).UnmarshalBinary(short); err == nil {
+		t.Error("OrderBookBody: expected error for short buffer")
+	}
+}
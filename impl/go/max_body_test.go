@@ -0,0 +1,58 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecoderMaxBodyBytes(t *testing.T) {
+	// A full frame of 255 trades fits comfortably under the default.
+	bodies := make([]interface{}, 255)
+	for i := range bodies {
+		bodies[i] = TradeBody{TradeID: uint32(i)}
+	}
+	frame, err := PackMessage(MsgTypeTrade, bodies...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := NewDecoder(bytes.NewReader(frame))
+	if _, _, err := decoder.Decode(); err != nil {
+		t.Fatalf("default limit rejected a full trade frame: %v", err)
+	}
+
+	// A tight custom limit rejects the same frame before reading bodies.
+	decoder = NewDecoder(bytes.NewReader(frame))
+	decoder.MaxBodyBytes(BodySizeFixed)
+	if _, _, err := decoder.Decode(); !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("err = %v, want ErrMessageTooLarge", err)
+	}
+}
+
+func TestDecoderMaxBodyBytesOrderBook(t *testing.T) {
+	const levels = 1000 // 4000 volume bytes
+	volumes := make([]uint32, levels)
+	frame, err := PackOrderBookBatch(
+		[]OrderBookBody{{TickerID: 1, FirstTick: 100, TickSize: 0.5, NumTicks: levels}},
+		[][]uint32{volumes},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := NewDecoder(bytes.NewReader(frame))
+	decoder.MaxBodyBytes(1024)
+	if _, _, err := decoder.Decode(); !errors.Is(err, ErrMessageTooLarge) {
+		t.Fatalf("err = %v, want ErrMessageTooLarge", err)
+	}
+
+	// The default budget covers any legal book (NumTicks caps at 65535).
+	decoder = NewDecoder(bytes.NewReader(frame))
+	_, decoded, err := decoder.Decode()
+	if err != nil {
+		t.Fatalf("default limit: %v", err)
+	}
+	if entry := decoded[0].(*OrderBookEntry); len(entry.Volumes) != levels {
+		t.Errorf("got %d volumes, want %d", len(entry.Volumes), levels)
+	}
+}
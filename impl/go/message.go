@@ -0,0 +1,91 @@
+// Streaming message writes via io.WriterTo.
+//
+// Writing a large order-book batch through PackOrderBookBatch builds the
+// whole byte slice before a single byte hits the connection. Message holds
+// a header and its bodies and streams them to a writer piece by piece, so
+// callers can io.Copy or hand it to anything accepting an io.WriterTo.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Message is a MITCH message held as structured data: a header plus its
+// bodies. Bodies follow the same conventions as Encoder.Encode: fixed-size
+// bodies by value or pointer, order books as *OrderBookEntry.
+type Message struct {
+	Header MitchHeader
+	Bodies []interface{}
+}
+
+// NewMessage builds a Message of the given type over bodies, stamping the
+// header with the current wall clock and the body count.
+func NewMessage(messageType MessageType, bodies ...interface{}) *Message {
+	return &Message{
+		Header: MitchHeader{
+			MessageType: messageType,
+			Timestamp:   WriteTimestamp48(nanosSinceMidnight(time.Now())),
+			Count:       uint8(len(bodies)),
+		},
+		Bodies: bodies,
+	}
+}
+
+// WriteTo streams the header then each body directly to w, satisfying
+// io.WriterTo. Bodies are encoded one at a time through a small scratch
+// buffer, so a large batch never materializes as one allocation.
+func (m *Message) WriteTo(w io.Writer) (int64, error) {
+	if len(m.Bodies) == 0 {
+		return 0, fmt.Errorf("mitch: %w to write", ErrNoBodies)
+	}
+	if len(m.Bodies) > 255 {
+		return 0, fmt.Errorf("mitch: %w: %d (max 255)", ErrTooManyBodies, len(m.Bodies))
+	}
+
+	var written int64
+	scratch := make([]byte, 0, BodySizeFixed)
+	n, err := w.Write(AppendHeader(scratch, m.Header))
+	written += int64(n)
+	if err != nil {
+		return written, fmt.Errorf("mitch: write header: %w", err)
+	}
+
+	for i, body := range m.Bodies {
+		var raw []byte
+		switch v := body.(type) {
+		case TradeBody:
+			raw = AppendTrade(scratch, v)
+		case *TradeBody:
+			raw = AppendTrade(scratch, *v)
+		case OrderBody:
+			raw = AppendOrder(scratch, v)
+		case *OrderBody:
+			raw = AppendOrder(scratch, *v)
+		case TickerBody:
+			raw = AppendTicker(scratch, v)
+		case *TickerBody:
+			raw = AppendTicker(scratch, *v)
+		case HeartbeatBody:
+			raw, _ = v.MarshalBinary()
+		case *HeartbeatBody:
+			raw, _ = v.MarshalBinary()
+		case *OrderBookEntry:
+			var err error
+			raw, err = appendBookWithVolumes(scratch, *v.Body, v.Volumes)
+			if err != nil {
+				return written, fmt.Errorf("mitch: write body %d: %w", i, err)
+			}
+		default:
+			return written, fmt.Errorf("mitch: write body %d: unsupported body type %T", i, body)
+		}
+		n, err := w.Write(raw)
+		written += int64(n)
+		if err != nil {
+			return written, fmt.Errorf("mitch: write body %d: %w", i, err)
+		}
+	}
+	return written, nil
+}
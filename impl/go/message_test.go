@@ -0,0 +1,72 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+var _ io.WriterTo = (*Message)(nil)
+
+func TestMessageWriteToTrades(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 2, Side: SideBuy}
+	message := NewMessage(MsgTypeTrade, trade, &trade)
+
+	var buf bytes.Buffer
+	n, err := message.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if n != int64(buf.Len()) || n != HeaderSize+2*BodySizeFixed {
+		t.Errorf("wrote %d bytes, buffer holds %d, want %d", n, buf.Len(), HeaderSize+2*BodySizeFixed)
+	}
+
+	header, bodies, err := UnpackMessage(buf.Bytes())
+	if err != nil {
+		t.Fatalf("UnpackMessage: %v", err)
+	}
+	if header.Count != 2 {
+		t.Errorf("Count = %d, want 2", header.Count)
+	}
+	for i := range bodies {
+		if got := *bodies[i].(*TradeBody); got != trade {
+			t.Errorf("body %d = %+v, want %+v", i, got, trade)
+		}
+	}
+}
+
+func TestMessageWriteToOrderBook(t *testing.T) {
+	entry := &OrderBookEntry{
+		Body:    &OrderBookBody{TickerID: 3, FirstTick: 10, TickSize: 1, NumTicks: 3, Side: SideSell},
+		Volumes: []uint32{7, 8, 9},
+	}
+	message := NewMessage(MsgTypeOrderBook, entry)
+
+	var buf bytes.Buffer
+	if _, err := message.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	_, book, volumes, err := UnpackOrderBook(buf.Bytes())
+	if err != nil {
+		t.Fatalf("UnpackOrderBook: %v", err)
+	}
+	if *book != *entry.Body || len(volumes) != 3 || volumes[2] != 9 {
+		t.Errorf("book = %+v volumes = %v", book, volumes)
+	}
+}
+
+func TestMessageWriteToErrors(t *testing.T) {
+	if _, err := NewMessage(MsgTypeTrade).WriteTo(io.Discard); !errors.Is(err, ErrNoBodies) {
+		t.Errorf("empty message: got %v, want ErrNoBodies", err)
+	}
+	if _, err := NewMessage(MsgTypeTrade, "bogus").WriteTo(io.Discard); err == nil {
+		t.Error("unsupported body type must error")
+	}
+
+	wantErr := errors.New("closed")
+	_, err := NewMessage(MsgTypeTrade, TradeBody{}).WriteTo(&failWriter{n: 1, err: wantErr})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("body write error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,222 @@
+// Package mitch implements the MITCH (Moded ITCH) binary protocol.
+//
+// Data structures for ultra-low latency financial market data transmission:
+// a fixed 8-byte header followed by an array of message-specific body
+// structures. See messaging.md for the complete specification.
+//
+// All multi-byte fields are serialized in big-endian byte order.
+package mitch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// MessageType is the message type code carried in the header's first
+// byte. Known codes are printable ASCII; String renders unknown codes as
+// "unknown(0xNN)" so logs stay readable for future types.
+type MessageType byte
+
+// MITCH message type codes (ASCII).
+const (
+	MsgTypeTrade     MessageType = 't' // trade executions (single or batch)
+	MsgTypeOrder     MessageType = 'o' // order events (single or batch)
+	MsgTypeTicker    MessageType = 's' // ticker/quote snapshots (single or batch)
+	MsgTypeOrderBook MessageType = 'b' // order book snapshots (variable length)
+)
+
+// String returns the human-readable name of the message type.
+func (m MessageType) String() string {
+	switch m {
+	case MsgTypeTrade:
+		return "trade"
+	case MsgTypeOrder:
+		return "order"
+	case MsgTypeTicker:
+		return "ticker"
+	case MsgTypeOrderBook:
+		return "orderbook"
+	case MsgTypeHeartbeat:
+		return "heartbeat"
+	case MsgTypeMixed:
+		return "mixed"
+	case MsgTypeBookChecksum:
+		return "bookchecksum"
+	}
+	return fmt.Sprintf("unknown(0x%02X)", byte(m))
+}
+
+// Order type encoding (bits 1-7 of TypeAndSide).
+const (
+	OrderTypeMarket = 0
+	OrderTypeLimit  = 1
+	OrderTypeStop   = 2
+	OrderTypeCancel = 3
+)
+
+// Order side encoding (bit 0 of TypeAndSide).
+const (
+	SideBuy  = 0
+	SideSell = 1
+)
+
+// Message size constants in bytes.
+const (
+	HeaderSize    = 8
+	BodySizeFixed = 32
+)
+
+// BodySizeVariable is the sentinel returned by BodySize for message types
+// whose bodies are not a fixed size.
+const BodySizeVariable = -1
+
+// BodySize returns the per-body size in bytes for a message type, so
+// external framers can pre-size buffers and validate frames. Order books
+// are variable length and return BodySizeVariable; unknown types return
+// ok=false.
+func BodySize(messageType MessageType) (int, bool) {
+	switch messageType {
+	case MsgTypeTrade, MsgTypeOrder, MsgTypeTicker:
+		return BodySizeFixed, true
+	case MsgTypeHeartbeat:
+		return HeartbeatBodySize, true
+	case MsgTypeBookChecksum:
+		return BookChecksumBodySize, true
+	case MsgTypeOrderBook:
+		return BodySizeVariable, true
+	}
+	return 0, false
+}
+
+// ByteOrder is the wire byte order for all multi-byte fields.
+var ByteOrder binary.ByteOrder = binary.BigEndian
+
+// MitchHeader is the unified 8-byte message header that prefixes every
+// MITCH message.
+type MitchHeader struct {
+	MessageType MessageType // ASCII message type ('t', 'o', 's', 'b')
+	Timestamp   [6]byte     // u48: nanoseconds since midnight UTC
+	Count       uint8       // number of body entries (1-255)
+}
+
+// TradeBody is a trade execution record (32 bytes).
+type TradeBody struct {
+	TickerID uint64  // 8-byte ticker identifier
+	Price    float64 // execution price
+	Quantity uint32  // executed volume
+	TradeID  uint32  // unique trade identifier
+	Side     uint8   // 0=Buy, 1=Sell
+	Padding  [7]byte // padding to 32 bytes
+}
+
+// OrderBody is an order lifecycle event (32 bytes).
+type OrderBody struct {
+	TickerID    uint64  // 8-byte ticker identifier
+	OrderID     uint32  // unique order identifier
+	Price       float64 // limit/stop price
+	Quantity    uint32  // order volume
+	TypeAndSide uint8   // combined order type (bits 1-7) and side (bit 0)
+	Expiry      [6]byte // u48: expiry timestamp, all-zero for GTC
+	Padding     [1]byte // padding to 32 bytes
+}
+
+// TickerBody is a ticker/quote snapshot (32 bytes, no padding).
+type TickerBody struct {
+	TickerID  uint64  // 8-byte ticker identifier
+	BidPrice  float64 // best bid price
+	AskPrice  float64 // best ask price
+	BidVolume uint32  // volume at best bid
+	AskVolume uint32  // volume at best ask
+}
+
+// OrderBookBody is the fixed 32-byte order book header. A complete order
+// book message carries NumTicks uint32 volume entries immediately after
+// this header, one per price level starting at FirstTick and spaced by
+// TickSize.
+type OrderBookBody struct {
+	TickerID  uint64  // 8-byte ticker identifier
+	FirstTick float64 // price of the first level
+	TickSize  float64 // price increment between levels
+	NumTicks  uint16  // number of volume entries following the header
+	Side      Side    // BookSideBid or BookSideAsk
+	Padding   [5]byte // padding to 32 bytes
+}
+
+// WriteTimestamp48 packs a nanoseconds-since-midnight timestamp into 6
+// big-endian bytes, masking anything above 48 bits.
+func WriteTimestamp48(timestamp uint64) [6]byte {
+	var ts [6]byte
+	ts[0] = byte(timestamp >> 40)
+	ts[1] = byte(timestamp >> 32)
+	ts[2] = byte(timestamp >> 24)
+	ts[3] = byte(timestamp >> 16)
+	ts[4] = byte(timestamp >> 8)
+	ts[5] = byte(timestamp)
+	return ts
+}
+
+// ReadTimestamp48 unpacks 6 big-endian bytes into a nanoseconds-since-midnight
+// timestamp.
+func ReadTimestamp48(ts [6]byte) uint64 {
+	return uint64(ts[0])<<40 | uint64(ts[1])<<32 | uint64(ts[2])<<24 |
+		uint64(ts[3])<<16 | uint64(ts[4])<<8 | uint64(ts[5])
+}
+
+// nanosSinceMidnight returns the nanoseconds elapsed since the most recent
+// UTC midnight.
+func nanosSinceMidnight(t time.Time) uint64 {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return uint64(t.Sub(midnight).Nanoseconds())
+}
+
+// PackMessage serializes a header and one or more same-type bodies into a
+// complete MITCH message. The header timestamp is taken from the current
+// wall clock.
+//
+// Note: for OrderBookBody only the fixed 32-byte header is written; use
+// PackOrderBook to include the volume array.
+func PackMessage(messageType MessageType, bodies ...interface{}) ([]byte, error) {
+	return PackMessageAt(time.Now(), messageType, bodies...)
+}
+
+// PackMessageAt packs like PackMessage with an explicit header timestamp
+// instead of the wall clock, so tests can assert exact bytes and replay
+// tools can preserve original capture times.
+func PackMessageAt(ts time.Time, messageType MessageType, bodies ...interface{}) ([]byte, error) {
+	return packMessageOrderedAt(ByteOrder, ts, messageType, bodies...)
+}
+
+// PackMessageChunked splits a batch of any size into several valid
+// messages of at most 255 bodies each, preserving order. Count is a uint8
+// so a single frame caps at 255; this lets callers publish e.g. a
+// 1000-trade snapshot without hitting the hard limit.
+func PackMessageChunked(messageType MessageType, bodies []interface{}) ([][]byte, error) {
+	if len(bodies) == 0 {
+		return nil, fmt.Errorf("mitch: %w to pack", ErrNoBodies)
+	}
+	messages := make([][]byte, 0, (len(bodies)+254)/255)
+	for start := 0; start < len(bodies); start += 255 {
+		end := start + 255
+		if end > len(bodies) {
+			end = len(bodies)
+		}
+		message, err := PackMessage(messageType, bodies[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// UnpackMessage deserializes a complete MITCH message into its header and
+// bodies. The returned slice holds *TradeBody, *OrderBody or *TickerBody
+// values depending on the message type.
+//
+// Order book messages are variable length and require a specialized
+// function; see UnpackOrderBook.
+func UnpackMessage(data []byte) (*MitchHeader, []interface{}, error) {
+	return UnpackMessageOrdered(ByteOrder, data)
+}
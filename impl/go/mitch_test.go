@@ -0,0 +1,145 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPackMessageChunked(t *testing.T) {
+	bodies := make([]interface{}, 1000)
+	for i := range bodies {
+		bodies[i] = TradeBody{TickerID: 1, TradeID: uint32(i)}
+	}
+
+	messages, err := PackMessageChunked(MsgTypeTrade, bodies)
+	if err != nil {
+		t.Fatalf("PackMessageChunked failed: %v", err)
+	}
+	if len(messages) != 4 { // 255+255+255+235
+		t.Fatalf("chunks = %d, want 4", len(messages))
+	}
+
+	// Order is preserved across chunks and every chunk is a valid message.
+	next := uint32(0)
+	for i, message := range messages {
+		header, decoded, err := UnpackMessage(message)
+		if err != nil {
+			t.Fatalf("chunk %d failed to unpack: %v", i, err)
+		}
+		if i < 3 && header.Count != 255 {
+			t.Errorf("chunk %d count = %d, want 255", i, header.Count)
+		}
+		for _, body := range decoded {
+			if id := body.(*TradeBody).TradeID; id != next {
+				t.Fatalf("trade ID %d out of order, want %d", id, next)
+			}
+			next++
+		}
+	}
+	if next != 1000 {
+		t.Errorf("decoded %d trades, want 1000", next)
+	}
+
+	// Exactly 255 bodies fits a single frame.
+	single, err := PackMessageChunked(MsgTypeTrade, bodies[:255])
+	if err != nil || len(single) != 1 {
+		t.Errorf("255 bodies: chunks=%d err=%v", len(single), err)
+	}
+}
+
+func TestBodySize(t *testing.T) {
+	cases := []struct {
+		messageType MessageType
+		size        int
+		ok          bool
+	}{
+		{MsgTypeTrade, BodySizeFixed, true},
+		{MsgTypeOrder, BodySizeFixed, true},
+		{MsgTypeTicker, BodySizeFixed, true},
+		{MsgTypeHeartbeat, HeartbeatBodySize, true},
+		{MsgTypeOrderBook, BodySizeVariable, true},
+		{0xFF, 0, false},
+		{'x', 0, false},
+	}
+	for _, tc := range cases {
+		size, ok := BodySize(tc.messageType)
+		if size != tc.size || ok != tc.ok {
+			t.Errorf("BodySize(%q) = (%d, %v), want (%d, %v)", tc.messageType, size, ok, tc.size, tc.ok)
+		}
+	}
+}
+
+func TestMessageTypeString(t *testing.T) {
+	cases := map[MessageType]string{
+		MsgTypeTrade:      "trade",
+		MsgTypeOrder:      "order",
+		MsgTypeTicker:     "ticker",
+		MsgTypeOrderBook:  "orderbook",
+		MsgTypeHeartbeat:  "heartbeat",
+		MsgTypeMixed:      "mixed",
+		MessageType(0x7F): "unknown(0x7F)",
+	}
+	for m, want := range cases {
+		if got := m.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", byte(m), got, want)
+		}
+	}
+}
+
+func TestPackMessageAtDeterministic(t *testing.T) {
+	ts := time.Date(2025, time.June, 1, 9, 30, 0, 123456789, time.UTC)
+
+	first, err := PackMessageAt(ts, MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := PackMessageAt(ts, MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("identical inputs must produce identical bytes")
+	}
+
+	header, _, err := UnpackMessage(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNanos := uint64(9*3600+30*60)*1_000_000_000 + 123456789
+	if got := ReadTimestamp48(header.Timestamp); got != wantNanos {
+		t.Errorf("timestamp = %d, want %d", got, wantNanos)
+	}
+}
+
+func TestUnpackMessageCountMismatch(t *testing.T) {
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1}, TradeBody{TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count claims two bodies but only one follows: fail fast with the
+	// exact expected/got sizes, not an EOF partway through the loop.
+	_, _, err = UnpackMessage(message[:HeaderSize+BodySizeFixed])
+	if err == nil {
+		t.Fatal("expected error for truncated buffer")
+	}
+	if !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("error = %v, want ErrShortBuffer", err)
+	}
+	if want := "expected 72 bytes for 2 bodies, got 40"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to mention %q", err, want)
+	}
+
+	// Trailing bytes beyond the declared frame are rejected too.
+	if _, _, err := UnpackMessage(append(message, 0)); err == nil {
+		t.Error("expected error for trailing bytes")
+	}
+
+	// The exact frame still unpacks.
+	if _, _, err := UnpackMessage(message); err != nil {
+		t.Errorf("exact frame failed: %v", err)
+	}
+}
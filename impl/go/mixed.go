@@ -0,0 +1,142 @@
+// Mixed-type message frames.
+//
+// The header's single MessageType byte forces every body in a frame to be
+// the same kind; sometimes a trade and the ticker update it caused belong
+// together. A mixed frame uses the reserved type 'x': its header Count is
+// the number of entries, and each body is prefixed by its own type byte.
+// Like the length-prefixed framing this is an extension outside the MITCH
+// spec and both ends must agree to use it; the single-type PackMessage
+// path is untouched.
+
+package mitch
+
+import (
+	"fmt"
+	"time"
+)
+
+// MsgTypeMixed marks a frame whose bodies each carry their own type byte.
+const MsgTypeMixed MessageType = 'x'
+
+// TypedBody pairs one body with its message type for mixed frames. Body
+// holds a TradeBody, OrderBody, TickerBody or HeartbeatBody (value or
+// pointer).
+type TypedBody struct {
+	Type MessageType
+	Body interface{}
+}
+
+// PackMixed serializes entries of differing types into one frame. Each
+// body is written as its type byte followed by its fixed-size encoding;
+// order books are variable length and not supported in mixed frames.
+func PackMixed(entries []TypedBody) ([]byte, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("mitch: %w to pack", ErrNoBodies)
+	}
+	if len(entries) > 255 {
+		return nil, fmt.Errorf("mitch: %w: %d (max 255)", ErrTooManyBodies, len(entries))
+	}
+
+	size := HeaderSize
+	for i, entry := range entries {
+		bodySize, ok := BodySize(entry.Type)
+		if !ok {
+			return nil, fmt.Errorf("mitch: entry %d: %w 0x%02X", i, ErrUnknownMessageType, entry.Type)
+		}
+		if bodySize == BodySizeVariable {
+			return nil, fmt.Errorf("mitch: entry %d: order books are not supported in mixed frames", i)
+		}
+		size += 1 + bodySize
+	}
+
+	data := make([]byte, 0, size)
+	data = AppendHeader(data, MitchHeader{
+		MessageType: MsgTypeMixed,
+		Timestamp:   WriteTimestamp48(nanosSinceMidnight(time.Now())),
+		Count:       uint8(len(entries)),
+	})
+	for i, entry := range entries {
+		data = append(data, byte(entry.Type))
+		var bodyType MessageType
+		switch v := entry.Body.(type) {
+		case TradeBody:
+			data, bodyType = AppendTrade(data, v), MsgTypeTrade
+		case *TradeBody:
+			data, bodyType = AppendTrade(data, *v), MsgTypeTrade
+		case OrderBody:
+			data, bodyType = AppendOrder(data, v), MsgTypeOrder
+		case *OrderBody:
+			data, bodyType = AppendOrder(data, *v), MsgTypeOrder
+		case TickerBody:
+			data, bodyType = AppendTicker(data, v), MsgTypeTicker
+		case *TickerBody:
+			data, bodyType = AppendTicker(data, *v), MsgTypeTicker
+		case HeartbeatBody:
+			raw, _ := v.MarshalBinary()
+			data, bodyType = append(data, raw...), MsgTypeHeartbeat
+		case *HeartbeatBody:
+			raw, _ := v.MarshalBinary()
+			data, bodyType = append(data, raw...), MsgTypeHeartbeat
+		}
+		if bodyType != entry.Type {
+			return nil, fmt.Errorf("mitch: entry %d: body type %T does not match type byte %q", i, entry.Body, entry.Type)
+		}
+	}
+	return data, nil
+}
+
+// UnpackMixed deserializes a mixed frame back into typed entries. Each
+// returned TypedBody holds a pointer body (*TradeBody etc.).
+func UnpackMixed(data []byte) (*MitchHeader, []TypedBody, error) {
+	if len(data) < HeaderSize {
+		return nil, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeMixed {
+		return nil, nil, fmt.Errorf("mitch: message type %q is not a mixed frame", header.MessageType)
+	}
+
+	entries := make([]TypedBody, 0, header.Count)
+	off := HeaderSize
+	for i := 0; i < int(header.Count); i++ {
+		if off >= len(data) {
+			return nil, nil, fmt.Errorf("mitch: %w: entry %d type byte at offset %d (len %d)", ErrShortBuffer, i, off, len(data))
+		}
+		entryType := MessageType(data[off])
+		off++
+		bodySize, ok := BodySize(entryType)
+		if !ok || bodySize == BodySizeVariable {
+			return nil, nil, fmt.Errorf("mitch: entry %d: %w 0x%02X", i, ErrUnknownMessageType, entryType)
+		}
+		if off+bodySize > len(data) {
+			return nil, nil, fmt.Errorf("mitch: %w: entry %d body needs %d bytes, %d remain", ErrShortBuffer, i, bodySize, len(data)-off)
+		}
+
+		var body interface{}
+		var err error
+		switch entryType {
+		case MsgTypeTrade:
+			b := new(TradeBody)
+			err = b.UnmarshalBinary(data[off:])
+			body = b
+		case MsgTypeOrder:
+			b := new(OrderBody)
+			err = b.UnmarshalBinary(data[off:])
+			body = b
+		case MsgTypeTicker:
+			b := new(TickerBody)
+			err = b.UnmarshalBinary(data[off:])
+			body = b
+		case MsgTypeHeartbeat:
+			b := new(HeartbeatBody)
+			err = b.UnmarshalBinary(data[off:])
+			body = b
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, TypedBody{Type: entryType, Body: body})
+		off += bodySize
+	}
+	return header, entries, nil
+}
@@ -0,0 +1,72 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPackMixedRoundTrip(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 7, Side: SideBuy}
+	ticker := TickerBody{TickerID: 1, BidPrice: 100.4, AskPrice: 100.6, BidVolume: 5, AskVolume: 8}
+	heartbeat := HeartbeatBody{Sequence: 42}
+
+	message, err := PackMixed([]TypedBody{
+		{Type: MsgTypeTrade, Body: trade},
+		{Type: MsgTypeTicker, Body: &ticker},
+		{Type: MsgTypeHeartbeat, Body: heartbeat},
+	})
+	if err != nil {
+		t.Fatalf("PackMixed: %v", err)
+	}
+
+	header, entries, err := UnpackMixed(message)
+	if err != nil {
+		t.Fatalf("UnpackMixed: %v", err)
+	}
+	if header.MessageType != MsgTypeMixed || header.Count != 3 {
+		t.Errorf("header = %+v", header)
+	}
+	if got := *entries[0].Body.(*TradeBody); got != trade {
+		t.Errorf("entry 0 = %+v, want %+v", got, trade)
+	}
+	if got := *entries[1].Body.(*TickerBody); got != ticker {
+		t.Errorf("entry 1 = %+v, want %+v", got, ticker)
+	}
+	if got := entries[2].Body.(*HeartbeatBody); got.Sequence != 42 {
+		t.Errorf("entry 2 sequence = %d, want 42", got.Sequence)
+	}
+}
+
+func TestPackMixedErrors(t *testing.T) {
+	if _, err := PackMixed(nil); !errors.Is(err, ErrNoBodies) {
+		t.Errorf("empty: got %v, want ErrNoBodies", err)
+	}
+	if _, err := PackMixed([]TypedBody{{Type: 'z', Body: TradeBody{}}}); !errors.Is(err, ErrUnknownMessageType) {
+		t.Errorf("unknown type: got %v, want ErrUnknownMessageType", err)
+	}
+	if _, err := PackMixed([]TypedBody{{Type: MsgTypeOrderBook, Body: OrderBookBody{}}}); err == nil {
+		t.Error("order books must be rejected in mixed frames")
+	}
+	if _, err := PackMixed([]TypedBody{{Type: MsgTypeTrade, Body: TickerBody{}}}); err == nil {
+		t.Error("type byte / body mismatch must error")
+	}
+}
+
+func TestUnpackMixedTruncated(t *testing.T) {
+	message, err := PackMixed([]TypedBody{{Type: MsgTypeTrade, Body: TradeBody{TradeID: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UnpackMixed(message[:len(message)-4]); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("truncated body: got %v, want ErrShortBuffer", err)
+	}
+	if _, _, err := UnpackMixed(message[:HeaderSize]); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("missing type byte: got %v, want ErrShortBuffer", err)
+	}
+
+	// A single-type frame is not a mixed frame.
+	plain, _ := PackMessage(MsgTypeTrade, TradeBody{})
+	if _, _, err := UnpackMixed(plain); err == nil {
+		t.Error("non-mixed frame must be rejected")
+	}
+}
@@ -0,0 +1,52 @@
+// Package pb holds the protobuf message types for MITCH gRPC interop.
+//
+// The structs mirror what protoc would generate from mitch.proto but are
+// written by hand so the module stays dependency-free: they carry the same
+// field set and proto field numbers in struct tags, and services that do
+// link the protobuf runtime can regenerate from mitch.proto and swap the
+// import without touching the conversion layer.
+package pb
+
+// Timestamp mirrors google.protobuf.Timestamp.
+type Timestamp struct {
+	Seconds int64 `protobuf:"1"`
+	Nanos   int32 `protobuf:"2"`
+}
+
+// Trade mirrors the mitch.pb.Trade message.
+type Trade struct {
+	TickerID uint64  `protobuf:"1"`
+	Price    float64 `protobuf:"2"`
+	Quantity uint32  `protobuf:"3"`
+	TradeID  uint32  `protobuf:"4"`
+	Side     uint32  `protobuf:"5"`
+}
+
+// Order mirrors the mitch.pb.Order message.
+type Order struct {
+	TickerID  uint64     `protobuf:"1"`
+	OrderID   uint32     `protobuf:"2"`
+	Price     float64    `protobuf:"3"`
+	Quantity  uint32     `protobuf:"4"`
+	OrderType uint32     `protobuf:"5"`
+	Side      uint32     `protobuf:"6"`
+	Expiry    *Timestamp `protobuf:"7"`
+}
+
+// Ticker mirrors the mitch.pb.Ticker message.
+type Ticker struct {
+	TickerID  uint64  `protobuf:"1"`
+	BidPrice  float64 `protobuf:"2"`
+	AskPrice  float64 `protobuf:"3"`
+	BidVolume uint32  `protobuf:"4"`
+	AskVolume uint32  `protobuf:"5"`
+}
+
+// OrderBook mirrors the mitch.pb.OrderBook message.
+type OrderBook struct {
+	TickerID  uint64   `protobuf:"1"`
+	FirstTick float64  `protobuf:"2"`
+	TickSize  float64  `protobuf:"3"`
+	Side      uint32   `protobuf:"4"`
+	Volumes   []uint32 `protobuf:"5"`
+}
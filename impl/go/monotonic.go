@@ -0,0 +1,91 @@
+// Clock-regression detection for monitoring consumers.
+//
+// Timestamps are nanoseconds since midnight UTC and reset once per day,
+// so "the timestamp went backward" is ambiguous: it is either a publisher
+// clock problem or the legitimate midnight wrap. MonotonicChecker tells
+// the two apart with a simple tunable heuristic — a drop is treated as a
+// wrap only when the previous timestamp was within WrapWindow of the end
+// of the day and the new one is within WrapWindow of the start. Anything
+// else is reported as a regression.
+
+package mitch
+
+import "time"
+
+// DefaultWrapWindow is how close to midnight both sides of a timestamp
+// drop must land for it to count as the daily wrap rather than a
+// regression.
+const DefaultWrapWindow = 5 * time.Minute
+
+// MonotonicChecker flags messages whose timestamp goes backward relative
+// to the previous one, excluding the midnight wrap. The zero value is
+// ready to use with DefaultWrapWindow; it is not safe for concurrent use.
+type MonotonicChecker struct {
+	// WrapWindow widens or narrows the midnight-wrap exemption. Zero
+	// means DefaultWrapWindow; feeds that batch heavily around midnight
+	// may need a larger window, strictly ordered feeds a smaller one.
+	WrapWindow time.Duration
+
+	// OnRegression, if non-nil, is called for every detected regression
+	// with the previous and offending timestamps (nanoseconds since
+	// midnight). Check reports regressions regardless, so a callback is
+	// only needed for side channels like logging or metrics.
+	OnRegression func(previous, current uint64)
+
+	last    uint64
+	primed  bool
+	flagged uint64
+}
+
+// Check feeds the next message's timestamp to the checker and reports
+// whether it regressed. The first timestamp seen never regresses. A
+// regressing timestamp still becomes the new reference point, so one bad
+// clock sample does not flag every message that follows it.
+func (m *MonotonicChecker) Check(timestamp uint64) bool {
+	if !m.primed {
+		m.primed = true
+		m.last = timestamp
+		return false
+	}
+	previous := m.last
+	m.last = timestamp
+	if timestamp >= previous || m.isWrap(previous, timestamp) {
+		return false
+	}
+	m.flagged++
+	if m.OnRegression != nil {
+		m.OnRegression(previous, timestamp)
+	}
+	return true
+}
+
+// CheckHeader is Check for a parsed header's 48-bit timestamp.
+func (m *MonotonicChecker) CheckHeader(header *MitchHeader) bool {
+	return m.Check(ReadTimestamp48(header.Timestamp))
+}
+
+// Regressions returns how many regressions have been flagged so far.
+func (m *MonotonicChecker) Regressions() uint64 { return m.flagged }
+
+// Reset clears the reference timestamp and the regression count, e.g.
+// when switching to a new stream.
+func (m *MonotonicChecker) Reset() {
+	m.last = 0
+	m.primed = false
+	m.flagged = 0
+}
+
+// isWrap reports whether a previous→current drop looks like the daily
+// midnight reset: previous within WrapWindow of end of day, current
+// within WrapWindow of the new day's start.
+func (m *MonotonicChecker) isWrap(previous, current uint64) bool {
+	window := m.WrapWindow
+	if window <= 0 {
+		window = DefaultWrapWindow
+	}
+	windowNanos := uint64(window / time.Nanosecond)
+	if windowNanos > nanosPerDay {
+		windowNanos = nanosPerDay
+	}
+	return previous >= nanosPerDay-windowNanos && current < windowNanos
+}
@@ -0,0 +1,109 @@
+package mitch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonotonicCheckerRegression(t *testing.T) {
+	second := uint64(time.Second / time.Nanosecond)
+	cases := []struct {
+		name       string
+		timestamps []uint64
+		regressed  []bool
+	}{
+		{
+			name:       "monotonic stream",
+			timestamps: []uint64{1 * second, 2 * second, 2 * second, 5 * second},
+			regressed:  []bool{false, false, false, false},
+		},
+		{
+			name:       "mid-day regression",
+			timestamps: []uint64{100 * second, 90 * second, 95 * second},
+			regressed:  []bool{false, true, false},
+		},
+		{
+			name: "midnight wrap is not a regression",
+			timestamps: []uint64{
+				nanosPerDay - 10*second,
+				3 * second,
+				4 * second,
+			},
+			regressed: []bool{false, false, false},
+		},
+		{
+			name: "large drop outside the wrap window",
+			timestamps: []uint64{
+				nanosPerDay / 2,
+				3 * second,
+			},
+			regressed: []bool{false, true},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var checker MonotonicChecker
+			for i, ts := range tc.timestamps {
+				if got := checker.Check(ts); got != tc.regressed[i] {
+					t.Errorf("Check(%d) [step %d] = %v, want %v", ts, i, got, tc.regressed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMonotonicCheckerWrapWindowTunable(t *testing.T) {
+	// With the default window a ten-minute pre-midnight gap is a
+	// regression; widening the window turns it into a wrap.
+	before := nanosPerDay - uint64(10*time.Minute/time.Nanosecond)
+	after := uint64(time.Second / time.Nanosecond)
+
+	strict := MonotonicChecker{}
+	strict.Check(before)
+	if !strict.Check(after) {
+		t.Error("default window: drop from 10min before midnight should regress")
+	}
+
+	lenient := MonotonicChecker{WrapWindow: 15 * time.Minute}
+	lenient.Check(before)
+	if lenient.Check(after) {
+		t.Error("15min window: the same drop should count as the midnight wrap")
+	}
+}
+
+func TestMonotonicCheckerCallbackAndCount(t *testing.T) {
+	var gotPrev, gotCur uint64
+	checker := MonotonicChecker{
+		OnRegression: func(previous, current uint64) {
+			gotPrev, gotCur = previous, current
+		},
+	}
+	checker.Check(500)
+	checker.Check(200)
+	if gotPrev != 500 || gotCur != 200 {
+		t.Errorf("callback saw (%d, %d), want (500, 200)", gotPrev, gotCur)
+	}
+	if checker.Regressions() != 1 {
+		t.Errorf("Regressions() = %d, want 1", checker.Regressions())
+	}
+
+	checker.Reset()
+	if checker.Check(100) {
+		t.Error("first timestamp after Reset must not regress")
+	}
+	if checker.Regressions() != 0 {
+		t.Errorf("Regressions() after Reset = %d, want 0", checker.Regressions())
+	}
+}
+
+func TestMonotonicCheckerHeader(t *testing.T) {
+	var checker MonotonicChecker
+	first := &MitchHeader{Timestamp: WriteTimestamp48(1000)}
+	second := &MitchHeader{Timestamp: WriteTimestamp48(400)}
+	if checker.CheckHeader(first) {
+		t.Error("first header flagged")
+	}
+	if !checker.CheckHeader(second) {
+		t.Error("backward header not flagged")
+	}
+}
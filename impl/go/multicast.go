@@ -0,0 +1,101 @@
+// UDP multicast distribution helpers.
+//
+// Market data is commonly fanned out over UDP multicast: each packed MITCH
+// message is sent as one datagram, so frames are self-delimiting and no
+// stream framing is needed. Delivery is best-effort; pair with the
+// sequence-number wrapper for gap detection.
+
+package mitch
+
+import (
+	"fmt"
+	"net"
+)
+
+// MaxDatagramSize is the largest message accepted per datagram. It is kept
+// under the common 1500-byte Ethernet MTU minus IP/UDP overhead so packets
+// are not fragmented; fragments multiply the effective loss rate.
+const MaxDatagramSize = 1400
+
+// MulticastPublisher sends packed messages as UDP datagrams to a group.
+type MulticastPublisher struct {
+	conn *net.UDPConn
+}
+
+// NewMulticastPublisher dials the given group address, e.g.
+// "239.0.0.1:5000".
+func NewMulticastPublisher(addr string) (*MulticastPublisher, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("mitch: multicast resolve: %w", err)
+	}
+	conn, err := net.DialUDP("udp", nil, udpAddr)
+	if err != nil {
+		return nil, fmt.Errorf("mitch: multicast dial: %w", err)
+	}
+	return &MulticastPublisher{conn: conn}, nil
+}
+
+// Publish writes one whole packed message as a single datagram. Messages
+// larger than MaxDatagramSize are rejected rather than fragmented.
+func (p *MulticastPublisher) Publish(data []byte) error {
+	if len(data) > MaxDatagramSize {
+		return fmt.Errorf("mitch: message of %d bytes exceeds datagram limit %d", len(data), MaxDatagramSize)
+	}
+	if _, err := p.conn.Write(data); err != nil {
+		return fmt.Errorf("mitch: multicast publish: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying socket.
+func (p *MulticastPublisher) Close() error {
+	return p.conn.Close()
+}
+
+// MulticastSubscriber receives packed messages from a UDP group.
+type MulticastSubscriber struct {
+	conn *net.UDPConn
+	buf  []byte
+}
+
+// NewMulticastSubscriber joins the given group address. A non-multicast
+// address falls back to a plain UDP listener, which is convenient for
+// loopback testing.
+func NewMulticastSubscriber(addr string) (*MulticastSubscriber, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("mitch: multicast resolve: %w", err)
+	}
+	var conn *net.UDPConn
+	if udpAddr.IP != nil && udpAddr.IP.IsMulticast() {
+		conn, err = net.ListenMulticastUDP("udp", nil, udpAddr)
+	} else {
+		conn, err = net.ListenUDP("udp", udpAddr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mitch: multicast listen: %w", err)
+	}
+	return &MulticastSubscriber{conn: conn, buf: make([]byte, MaxDatagramSize)}, nil
+}
+
+// Receive reads one datagram and returns a copy of the full message bytes.
+func (s *MulticastSubscriber) Receive() ([]byte, error) {
+	n, _, err := s.conn.ReadFromUDP(s.buf)
+	if err != nil {
+		return nil, fmt.Errorf("mitch: multicast receive: %w", err)
+	}
+	message := make([]byte, n)
+	copy(message, s.buf[:n])
+	return message, nil
+}
+
+// LocalAddr returns the address the subscriber is bound to.
+func (s *MulticastSubscriber) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+// Close leaves the group and closes the socket.
+func (s *MulticastSubscriber) Close() error {
+	return s.conn.Close()
+}
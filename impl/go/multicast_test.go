@@ -0,0 +1,55 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMulticastPublishReceiveLoopback(t *testing.T) {
+	sub, err := NewMulticastSubscriber("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("subscriber failed: %v", err)
+	}
+	defer sub.Close()
+
+	pub, err := NewMulticastPublisher(sub.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("publisher failed: %v", err)
+	}
+	defer pub.Close()
+
+	message, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 1, BidPrice: 2, AskPrice: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Publish(message); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	sub.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	got, err := sub.Receive()
+	if err != nil {
+		t.Fatalf("receive failed: %v", err)
+	}
+	if !bytes.Equal(got, message) {
+		t.Errorf("received %x, want %x", got, message)
+	}
+}
+
+func TestMulticastPublishSizeLimit(t *testing.T) {
+	sub, err := NewMulticastSubscriber("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	pub, err := NewMulticastPublisher(sub.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	if err := pub.Publish(make([]byte, MaxDatagramSize+1)); err == nil {
+		t.Error("expected error for message exceeding the datagram limit")
+	}
+}
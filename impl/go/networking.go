@@ -0,0 +1,131 @@
+// MITCH transport helpers for raw TCP feeds.
+//
+// These functions move whole packed messages over a net.Conn; they do not
+// interpret body contents beyond what is needed to frame a message.
+
+package mitch
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// MitchSendTCP writes a complete packed message to the connection.
+func MitchSendTCP(conn net.Conn, data []byte) error {
+	for len(data) > 0 {
+		n, err := conn.Write(data)
+		if err != nil {
+			return fmt.Errorf("mitch: send: %w", err)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// MitchRecvTCP reads exactly length bytes from the connection.
+func MitchRecvTCP(conn net.Conn, length int) ([]byte, error) {
+	buf, _, err := recvFull(conn, length)
+	return buf, err
+}
+
+// recvFull reads exactly length bytes, additionally returning how many
+// bytes were consumed when the read fails partway.
+func recvFull(conn net.Conn, length int) ([]byte, int, error) {
+	buf := make([]byte, length)
+	n, err := io.ReadFull(conn, buf)
+	if err != nil {
+		return nil, n, fmt.Errorf("mitch: recv: %w", err)
+	}
+	return buf, n, nil
+}
+
+// PartialReadError reports a message receive that failed after consuming
+// some bytes, leaving the connection mid-message: the next byte on the
+// wire is not a header, so continuing to read messages would produce
+// garbage. Consumed is the total number of bytes read for the failed
+// message, letting the caller log its stream offset before resyncing —
+// which for a raw MITCH stream means reconnecting, as the format carries
+// no sync markers to scan for.
+type PartialReadError struct {
+	Consumed int
+	Err      error
+}
+
+func (e *PartialReadError) Error() string {
+	return fmt.Sprintf("mitch: recv: stream desynchronized after %d bytes: %v", e.Consumed, e.Err)
+}
+
+func (e *PartialReadError) Unwrap() error { return e.Err }
+
+// MitchRecvMessage reads one complete MITCH message (header plus bodies)
+// from the connection and returns the raw bytes. Fixed-size types read
+// Count*32 bytes; order books are variable length, so each book's 32-byte
+// header is read first and its NumTicks determines how many volume bytes
+// follow. Reading the wrong length would desynchronize the stream and
+// corrupt every subsequent message.
+//
+// Post-error stream state: an error with zero bytes consumed (a clean
+// failure before any header byte arrived) leaves the connection at a
+// message boundary and a retry is safe. Any other failure is returned as
+// a *PartialReadError and leaves the connection mid-message; the caller
+// must not retry on the same connection and should resync, typically by
+// reconnecting.
+func MitchRecvMessage(conn net.Conn) ([]byte, error) {
+	consumed := 0
+	message, n, err := recvFull(conn, HeaderSize)
+	if err != nil {
+		if n == 0 {
+			return nil, err
+		}
+		return nil, &PartialReadError{Consumed: n, Err: err}
+	}
+	consumed += n
+	messageType := MessageType(message[0])
+	count := message[7]
+
+	recv := func(length int) ([]byte, error) {
+		chunk, n, err := recvFull(conn, length)
+		consumed += n
+		if err != nil {
+			return nil, &PartialReadError{Consumed: consumed, Err: err}
+		}
+		return chunk, nil
+	}
+
+	switch messageType {
+	case MsgTypeOrderBook:
+		for i := 0; i < int(count); i++ {
+			bookBytes, err := recv(BodySizeFixed)
+			if err != nil {
+				return nil, err
+			}
+			message = append(message, bookBytes...)
+			numTicks := ByteOrder.Uint16(bookBytes[24:26])
+			volumeBytes, err := recv(int(numTicks) * 4)
+			if err != nil {
+				return nil, err
+			}
+			message = append(message, volumeBytes...)
+		}
+	case MsgTypeHeartbeat:
+		bodyBytes, err := recv(int(count) * HeartbeatBodySize)
+		if err != nil {
+			return nil, err
+		}
+		message = append(message, bodyBytes...)
+	case MsgTypeBookChecksum:
+		bodyBytes, err := recv(int(count) * BookChecksumBodySize)
+		if err != nil {
+			return nil, err
+		}
+		message = append(message, bodyBytes...)
+	default:
+		bodyBytes, err := recv(int(count) * BodySizeFixed)
+		if err != nil {
+			return nil, err
+		}
+		message = append(message, bodyBytes...)
+	}
+	return message, nil
+}
@@ -0,0 +1,103 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestMitchRecvMessageInterleaved(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	trades, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 10, Quantity: 100, TradeID: 1, Side: SideBuy},
+		TradeBody{TickerID: 1, Price: 11, Quantity: 50, TradeID: 2, Side: SideSell},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 2, FirstTick: 100, TickSize: 0.5, NumTicks: 7, Side: SideSell},
+		[]uint32{1, 2, 3, 4, 5, 6, 7},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moreTrades, err := PackMessage(MsgTypeTrade,
+		TradeBody{TickerID: 1, Price: 12, Quantity: 25, TradeID: 3, Side: SideBuy},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for _, msg := range [][]byte{trades, book, moreTrades} {
+			if err := MitchSendTCP(server, msg); err != nil {
+				t.Errorf("send failed: %v", err)
+				return
+			}
+		}
+	}()
+
+	// Each message must come back byte-exact: if the order book frame were
+	// sized as Count*32 the stream would desynchronize and the third
+	// message would be corrupt.
+	for i, want := range [][]byte{trades, book, moreTrades} {
+		got, err := MitchRecvMessage(client)
+		if err != nil {
+			t.Fatalf("recv message %d failed: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("message %d = %x, want %x", i, got, want)
+		}
+	}
+
+	if _, _, _, err := UnpackOrderBook(book); err != nil {
+		t.Errorf("received order book failed to decode: %v", err)
+	}
+}
+
+func TestMitchRecvMessagePartialRead(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	frame, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1}, TradeBody{TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deliver the header and half of the first body, then hang up.
+	go func() {
+		server.Write(frame[:HeaderSize+BodySizeFixed/2])
+		server.Close()
+	}()
+
+	_, err = MitchRecvMessage(client)
+	var partial *PartialReadError
+	if !errors.As(err, &partial) {
+		t.Fatalf("err = %v, want *PartialReadError", err)
+	}
+	if want := HeaderSize + BodySizeFixed/2; partial.Consumed != want {
+		t.Errorf("Consumed = %d, want %d", partial.Consumed, want)
+	}
+}
+
+func TestMitchRecvMessageCleanEOF(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	// Closing before any byte arrives leaves the stream at a boundary, so
+	// the failure must not be a PartialReadError.
+	go server.Close()
+
+	_, err := MitchRecvMessage(client)
+	if err == nil {
+		t.Fatal("recv on closed conn must error")
+	}
+	var partial *PartialReadError
+	if errors.As(err, &partial) {
+		t.Errorf("zero-byte failure reported as partial read: %v", err)
+	}
+}
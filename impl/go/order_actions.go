@@ -0,0 +1,76 @@
+// Order lifecycle semantics: new, modify and cancel events.
+//
+// The wire keeps the 32-byte OrderBody layout; lifecycle information rides
+// in the order type bits of TypeAndSide. OrderTypeModify joins the existing
+// constants so a consumer can distinguish a brand-new order from a replace,
+// with the body carrying the original OrderID plus the new price/quantity.
+
+package mitch
+
+// OrderTypeModify marks a replace of an existing order: OrderID names the
+// original order and Price/Quantity carry the new values.
+const OrderTypeModify = 4
+
+// OrderAction classifies an order event by lifecycle stage.
+type OrderAction uint8
+
+const (
+	ActionNew    OrderAction = iota // market, limit or stop order placement
+	ActionModify                    // replace of an existing order
+	ActionCancel                    // cancellation of an existing order
+)
+
+// String returns the action name.
+func (a OrderAction) String() string {
+	switch a {
+	case ActionNew:
+		return "new"
+	case ActionModify:
+		return "modify"
+	case ActionCancel:
+		return "cancel"
+	}
+	return "unknown"
+}
+
+// Action derives the lifecycle action from the order type bits.
+func (o *OrderBody) Action() OrderAction {
+	switch o.TypeAndSide >> 1 {
+	case OrderTypeCancel:
+		return ActionCancel
+	case OrderTypeModify:
+		return ActionModify
+	}
+	return ActionNew
+}
+
+// NewModifyOrder builds an order event replacing orderID with a new price
+// and quantity.
+func NewModifyOrder(tickerID uint64, orderID uint32, newPrice float64, newQuantity uint32, side uint8) OrderBody {
+	return OrderBody{
+		TickerID:    tickerID,
+		OrderID:     orderID,
+		Price:       newPrice,
+		Quantity:    newQuantity,
+		TypeAndSide: OrderTypeModify<<1 | side&0x01,
+	}
+}
+
+// NewCancelOrder builds an order event cancelling orderID.
+func NewCancelOrder(tickerID uint64, orderID uint32, side uint8) OrderBody {
+	return OrderBody{
+		TickerID:    tickerID,
+		OrderID:     orderID,
+		TypeAndSide: OrderTypeCancel<<1 | side&0x01,
+	}
+}
+
+// PackModifyOrder packs a single modify-order message.
+func PackModifyOrder(tickerID uint64, orderID uint32, newPrice float64, newQuantity uint32, side uint8) ([]byte, error) {
+	return PackMessage(MsgTypeOrder, NewModifyOrder(tickerID, orderID, newPrice, newQuantity, side))
+}
+
+// PackCancelOrder packs a single cancel-order message.
+func PackCancelOrder(tickerID uint64, orderID uint32, side uint8) ([]byte, error) {
+	return PackMessage(MsgTypeOrder, NewCancelOrder(tickerID, orderID, side))
+}
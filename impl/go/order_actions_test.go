@@ -0,0 +1,48 @@
+package mitch
+
+import "testing"
+
+func TestOrderActions(t *testing.T) {
+	limit := OrderBody{TypeAndSide: OrderTypeLimit<<1 | SideBuy}
+	if limit.Action() != ActionNew {
+		t.Errorf("limit action = %v, want new", limit.Action())
+	}
+
+	modify := NewModifyOrder(1, 42, 101.5, 200, SideSell)
+	if modify.Action() != ActionModify {
+		t.Errorf("modify action = %v", modify.Action())
+	}
+	if modify.OrderID != 42 || modify.Price != 101.5 || modify.Quantity != 200 {
+		t.Errorf("modify = %+v", modify)
+	}
+	if modify.TypeAndSide&0x01 != SideSell {
+		t.Errorf("modify side = %d, want sell", modify.TypeAndSide&0x01)
+	}
+
+	cancel := NewCancelOrder(1, 42, SideBuy)
+	if cancel.Action() != ActionCancel {
+		t.Errorf("cancel action = %v", cancel.Action())
+	}
+}
+
+func TestPackModifyOrderRoundTrip(t *testing.T) {
+	data, err := PackModifyOrder(7, 99, 10.5, 50, SideBuy)
+	if err != nil {
+		t.Fatalf("PackModifyOrder failed: %v", err)
+	}
+	_, bodies, err := UnpackMessage(data)
+	if err != nil {
+		t.Fatalf("unpack failed: %v", err)
+	}
+	order := bodies[0].(*OrderBody)
+	if order.Action() != ActionModify || order.OrderID != 99 {
+		t.Errorf("order = %+v action=%v", order, order.Action())
+	}
+	// The wire layout must stay the standard 32 bytes.
+	if len(data) != HeaderSize+BodySizeFixed {
+		t.Errorf("message size = %d, want %d", len(data), HeaderSize+BodySizeFixed)
+	}
+	if err := order.Validate(); err != nil {
+		t.Errorf("modify order failed validation: %v", err)
+	}
+}
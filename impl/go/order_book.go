@@ -0,0 +1,319 @@
+// MITCH order book message packing.
+//
+// Order book messages ('b') are variable length: a fixed 32-byte
+// OrderBookBody followed by NumTicks uint32 volume entries, so they cannot
+// go through the generic fixed-size PackMessage/UnpackMessage path.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// PackOrderBook serializes a complete order book message: the 8-byte header,
+// the 32-byte book header and NumTicks big-endian uint32 volumes. The header
+// Count is forced to 1 and the timestamp is taken from the current wall
+// clock. len(volumes) must equal book.NumTicks.
+func PackOrderBook(header MitchHeader, book OrderBookBody, volumes []uint32) ([]byte, error) {
+	if book.NumTicks == 0 {
+		return nil, fmt.Errorf("mitch: order book has zero ticks")
+	}
+	if len(volumes) != int(book.NumTicks) {
+		return nil, fmt.Errorf("mitch: volume count %d does not match NumTicks %d", len(volumes), book.NumTicks)
+	}
+
+	header.MessageType = MsgTypeOrderBook
+	header.Count = 1
+	if ReadTimestamp48(header.Timestamp) == 0 {
+		header.Timestamp = WriteTimestamp48(nanosSinceMidnight(time.Now()))
+	}
+
+	data := make([]byte, HeaderSize+BodySizeFixed+len(volumes)*4)
+	packHeaderInto(data, header)
+	packOrderBookBodyInto(data[HeaderSize:], book)
+	off := HeaderSize + BodySizeFixed
+	for _, v := range volumes {
+		ByteOrder.PutUint32(data[off:off+4], v)
+		off += 4
+	}
+	return data, nil
+}
+
+// MaxOrderBookLevels caps the NumTicks an unpacker accepts per book.
+// NumTicks is uint16, so a corrupt header can claim 65535 levels (~256 KB
+// of volumes) — far deeper than any real instrument's grid. Books over the
+// cap are rejected before the volume slice is allocated. Integrators on
+// shallow instruments can lower it; raise it only if a feed genuinely
+// publishes deeper grids.
+var MaxOrderBookLevels = 10000
+
+// checkBookLevels rejects a book claiming more levels than
+// MaxOrderBookLevels allows.
+func checkBookLevels(numTicks uint16) error {
+	if int(numTicks) > MaxOrderBookLevels {
+		return fmt.Errorf("mitch: %w: order book claims %d levels (max %d)", ErrMessageTooLarge, numTicks, MaxOrderBookLevels)
+	}
+	return nil
+}
+
+// UnpackOrderBook deserializes a complete order book message: the 8-byte
+// header, the 32-byte book header and NumTicks big-endian uint32 volumes.
+// The buffer must hold at least 8 + 32 + NumTicks*4 bytes.
+func UnpackOrderBook(data []byte) (*MitchHeader, *OrderBookBody, []uint32, error) {
+	if len(data) < HeaderSize+BodySizeFixed {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: order book message is %d bytes (need at least %d)", ErrShortBuffer, len(data), HeaderSize+BodySizeFixed)
+	}
+
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeOrderBook {
+		return nil, nil, nil, fmt.Errorf("mitch: message type %q is not an order book", header.MessageType)
+	}
+
+	book := unpackOrderBookBody(data[HeaderSize:])
+	if err := book.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return nil, nil, nil, err
+	}
+	expected := HeaderSize + BodySizeFixed + int(book.NumTicks)*4
+	if len(data) < expected {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d ticks, got %d", ErrShortBuffer, expected, book.NumTicks, len(data))
+	}
+
+	volumes := make([]uint32, book.NumTicks)
+	off := HeaderSize + BodySizeFixed
+	for i := range volumes {
+		volumes[i] = ByteOrder.Uint32(data[off : off+4])
+		off += 4
+	}
+	return header, book, volumes, nil
+}
+
+// UnpackOrderBookInto deserializes a complete order book message like
+// UnpackOrderBook, but decodes into the caller's book and volume slice:
+// *volumes is resliced to NumTicks and only grown when its capacity is
+// short, so a consumer looping over thousands of updates reuses one
+// buffer and allocates nothing in steady state.
+func UnpackOrderBookInto(data []byte, book *OrderBookBody, volumes *[]uint32) error {
+	if len(data) < HeaderSize+BodySizeFixed {
+		return fmt.Errorf("mitch: %w: order book message is %d bytes (need at least %d)", ErrShortBuffer, len(data), HeaderSize+BodySizeFixed)
+	}
+	if MessageType(data[0]) != MsgTypeOrderBook {
+		return fmt.Errorf("mitch: message type %q is not an order book", MessageType(data[0]))
+	}
+
+	if err := book.UnmarshalBinary(data[HeaderSize:]); err != nil {
+		return err
+	}
+	if err := book.Validate(); err != nil {
+		return err
+	}
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return err
+	}
+	expected := HeaderSize + BodySizeFixed + int(book.NumTicks)*4
+	if len(data) < expected {
+		return fmt.Errorf("mitch: %w: expected %d bytes for %d ticks, got %d", ErrShortBuffer, expected, book.NumTicks, len(data))
+	}
+
+	n := int(book.NumTicks)
+	if cap(*volumes) < n {
+		*volumes = make([]uint32, n)
+	}
+	*volumes = (*volumes)[:n]
+	off := HeaderSize + BodySizeFixed
+	for i := range *volumes {
+		(*volumes)[i] = ByteOrder.Uint32(data[off : off+4])
+		off += 4
+	}
+	return nil
+}
+
+// PackOrderBookBatch serializes several order books into a single message.
+// Each book is written as its 32-byte header followed by its own volume
+// array, so the bodies are variable length and concatenated back to back.
+// The header Count is set to len(books).
+func PackOrderBookBatch(books []OrderBookBody, volumes [][]uint32) ([]byte, error) {
+	if len(books) == 0 {
+		return nil, fmt.Errorf("mitch: %w to pack", ErrNoBodies)
+	}
+	if len(books) > 255 {
+		return nil, fmt.Errorf("mitch: %w: %d books (max 255)", ErrTooManyBodies, len(books))
+	}
+	if len(volumes) != len(books) {
+		return nil, fmt.Errorf("mitch: volume slice count %d does not match book count %d", len(volumes), len(books))
+	}
+
+	size := HeaderSize
+	for i, book := range books {
+		if book.NumTicks == 0 {
+			return nil, fmt.Errorf("mitch: book %d has zero ticks", i)
+		}
+		if len(volumes[i]) != int(book.NumTicks) {
+			return nil, fmt.Errorf("mitch: book %d volume count %d does not match NumTicks %d", i, len(volumes[i]), book.NumTicks)
+		}
+		size += BodySizeFixed + int(book.NumTicks)*4
+	}
+
+	header := MitchHeader{
+		MessageType: MsgTypeOrderBook,
+		Timestamp:   WriteTimestamp48(nanosSinceMidnight(time.Now())),
+		Count:       uint8(len(books)),
+	}
+
+	data := make([]byte, size)
+	packHeaderInto(data, header)
+	off := HeaderSize
+	for i, book := range books {
+		packOrderBookBodyInto(data[off:], book)
+		off += BodySizeFixed
+		for _, v := range volumes[i] {
+			ByteOrder.PutUint32(data[off:off+4], v)
+			off += 4
+		}
+	}
+	return data, nil
+}
+
+// UnpackOrderBookBatch deserializes a message holding one or more order
+// books, walking the buffer one book header at a time and using each book's
+// NumTicks to advance. It errors out if any book would overrun the buffer.
+func UnpackOrderBookBatch(data []byte) (*MitchHeader, []*OrderBookBody, [][]uint32, error) {
+	if len(data) < HeaderSize {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeOrderBook {
+		return nil, nil, nil, fmt.Errorf("mitch: message type %q is not an order book", header.MessageType)
+	}
+
+	books := make([]*OrderBookBody, 0, header.Count)
+	volumes := make([][]uint32, 0, header.Count)
+	off := HeaderSize
+	for i := 0; i < int(header.Count); i++ {
+		if off+BodySizeFixed > len(data) {
+			return nil, nil, nil, fmt.Errorf("mitch: %w: book %d header overruns buffer at offset %d (len %d)", ErrShortBuffer, i, off, len(data))
+		}
+		book := unpackOrderBookBody(data[off:])
+		if err := book.Validate(); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := checkBookLevels(book.NumTicks); err != nil {
+			return nil, nil, nil, err
+		}
+		off += BodySizeFixed
+
+		end := off + int(book.NumTicks)*4
+		if end > len(data) {
+			return nil, nil, nil, fmt.Errorf("mitch: %w: book %d volumes need %d bytes, %d remain", ErrShortBuffer, i, int(book.NumTicks)*4, len(data)-off)
+		}
+		vols := make([]uint32, book.NumTicks)
+		for j := range vols {
+			vols[j] = ByteOrder.Uint32(data[off : off+4])
+			off += 4
+		}
+		books = append(books, book)
+		volumes = append(volumes, vols)
+	}
+	return header, books, volumes, nil
+}
+
+// LevelPrice returns the price of level i without bounds checking the
+// volume slice. Levels are stored best-first: an ask book walks ascending
+// from FirstTick (the best ask) while a bid book walks descending from
+// FirstTick (the best bid).
+func (b *OrderBookBody) LevelPrice(i int) float64 {
+	if b.Side == SideBuy {
+		return b.FirstTick - float64(i)*b.TickSize
+	}
+	return b.FirstTick + float64(i)*b.TickSize
+}
+
+// Level returns the price and volume of level i, bounds-checking i against
+// NumTicks and the supplied volume slice.
+func (b *OrderBookBody) Level(i int, volumes []uint32) (price float64, volume uint32, err error) {
+	if i < 0 || i >= int(b.NumTicks) {
+		return 0, 0, fmt.Errorf("mitch: level %d out of range (book has %d ticks)", i, b.NumTicks)
+	}
+	if i >= len(volumes) {
+		return 0, 0, fmt.Errorf("mitch: level %d out of range (volume slice has %d entries)", i, len(volumes))
+	}
+	return b.LevelPrice(i), volumes[i], nil
+}
+
+// Iterate calls fn for each level in storage order, best level first on
+// both sides: ascending prices for asks, descending for bids. Iteration
+// stops at the shorter of NumTicks and the volume slice.
+func (b *OrderBookBody) Iterate(volumes []uint32, fn func(price float64, volume uint32)) {
+	n := int(b.NumTicks)
+	if len(volumes) < n {
+		n = len(volumes)
+	}
+	for i := 0; i < n; i++ {
+		fn(b.LevelPrice(i), volumes[i])
+	}
+}
+
+// BestLevel returns the book's top of book: the first populated level in
+// storage order, which is the highest-priced bid or lowest-priced ask.
+// Zero-volume levels are grid placeholders and are skipped; ok is false
+// when the book is empty or every level is zero.
+func (b *OrderBookBody) BestLevel(volumes []uint32) (price float64, volume uint32, ok bool) {
+	n := int(b.NumTicks)
+	if len(volumes) < n {
+		n = len(volumes)
+	}
+	for i := 0; i < n; i++ {
+		if volumes[i] != 0 {
+			return b.LevelPrice(i), volumes[i], true
+		}
+	}
+	return 0, 0, false
+}
+
+// unpackHeader reads the 8-byte header from the start of data.
+func unpackHeader(data []byte) *MitchHeader {
+	header := &MitchHeader{
+		MessageType: MessageType(data[0]),
+		Count:       data[7],
+	}
+	copy(header.Timestamp[:], data[1:7])
+	return header
+}
+
+// unpackOrderBookBody reads the fixed 32-byte book header from the start
+// of data.
+func unpackOrderBookBody(data []byte) *OrderBookBody {
+	book := &OrderBookBody{
+		TickerID:  ByteOrder.Uint64(data[0:8]),
+		FirstTick: math.Float64frombits(ByteOrder.Uint64(data[8:16])),
+		TickSize:  math.Float64frombits(ByteOrder.Uint64(data[16:24])),
+		NumTicks:  ByteOrder.Uint16(data[24:26]),
+		Side:      Side(data[26]),
+	}
+	copy(book.Padding[:], data[27:32])
+	return book
+}
+
+// packHeaderInto writes the 8-byte header at the start of dst.
+func packHeaderInto(dst []byte, header MitchHeader) {
+	dst[0] = byte(header.MessageType)
+	copy(dst[1:7], header.Timestamp[:])
+	dst[7] = header.Count
+}
+
+// packOrderBookBodyInto writes the fixed 32-byte book header at the start
+// of dst.
+func packOrderBookBodyInto(dst []byte, book OrderBookBody) {
+	ByteOrder.PutUint64(dst[0:8], book.TickerID)
+	ByteOrder.PutUint64(dst[8:16], math.Float64bits(book.FirstTick))
+	ByteOrder.PutUint64(dst[16:24], math.Float64bits(book.TickSize))
+	ByteOrder.PutUint16(dst[24:26], book.NumTicks)
+	dst[26] = byte(book.Side)
+	pad := sanitizedBookPadding(book.Padding)
+	copy(dst[27:32], pad[:])
+}
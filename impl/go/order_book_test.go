@@ -0,0 +1,309 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPackOrderBookSize(t *testing.T) {
+	book := OrderBookBody{
+		TickerID:  0x1234567890ABCDEF,
+		FirstTick: 100.0,
+		TickSize:  0.5,
+		NumTicks:  50,
+		Side:      SideBuy,
+	}
+	volumes := make([]uint32, 50)
+	for i := range volumes {
+		volumes[i] = uint32(i + 1)
+	}
+
+	data, err := PackOrderBook(MitchHeader{}, book, volumes)
+	if err != nil {
+		t.Fatalf("PackOrderBook failed: %v", err)
+	}
+	want := HeaderSize + BodySizeFixed + 50*4
+	if len(data) != want {
+		t.Errorf("packed size = %d, want %d", len(data), want)
+	}
+	if data[0] != byte(MsgTypeOrderBook) {
+		t.Errorf("message type = %q, want %q", data[0], MsgTypeOrderBook)
+	}
+	if data[7] != 1 {
+		t.Errorf("count = %d, want 1", data[7])
+	}
+}
+
+func TestUnpackOrderBookRoundTrip(t *testing.T) {
+	book := OrderBookBody{
+		TickerID:  42,
+		FirstTick: 99.5,
+		TickSize:  0.25,
+		NumTicks:  8,
+		Side:      SideSell,
+	}
+	volumes := []uint32{1, 0, 3, 0, 5, 6, 7, 8}
+
+	data, err := PackOrderBook(MitchHeader{}, book, volumes)
+	if err != nil {
+		t.Fatalf("PackOrderBook failed: %v", err)
+	}
+	header, got, gotVols, err := UnpackOrderBook(data)
+	if err != nil {
+		t.Fatalf("UnpackOrderBook failed: %v", err)
+	}
+	if header.MessageType != MsgTypeOrderBook || header.Count != 1 {
+		t.Errorf("header = %+v", header)
+	}
+	if *got != book {
+		t.Errorf("book = %+v, want %+v", *got, book)
+	}
+	if len(gotVols) != len(volumes) {
+		t.Fatalf("volumes length = %d, want %d", len(gotVols), len(volumes))
+	}
+	for i := range volumes {
+		if gotVols[i] != volumes[i] {
+			t.Errorf("volume[%d] = %d, want %d", i, gotVols[i], volumes[i])
+		}
+	}
+}
+
+func TestUnpackOrderBookShortBuffer(t *testing.T) {
+	book := OrderBookBody{FirstTick: 1, TickSize: 1, NumTicks: 4, Side: SideBuy}
+	data, err := PackOrderBook(MitchHeader{}, book, []uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("PackOrderBook failed: %v", err)
+	}
+	if _, _, _, err := UnpackOrderBook(data[:len(data)-3]); err == nil {
+		t.Error("expected error for truncated volume array")
+	}
+	if _, _, _, err := UnpackOrderBook(data[:10]); err == nil {
+		t.Error("expected error for truncated book header")
+	}
+}
+
+func TestOrderBookBatchRoundTrip(t *testing.T) {
+	books := []OrderBookBody{
+		{TickerID: 1, FirstTick: 10, TickSize: 0.1, NumTicks: 3, Side: SideBuy},
+		{TickerID: 2, FirstTick: 20, TickSize: 0.5, NumTicks: 5, Side: SideSell},
+	}
+	volumes := [][]uint32{{1, 2, 3}, {10, 20, 30, 40, 50}}
+
+	data, err := PackOrderBookBatch(books, volumes)
+	if err != nil {
+		t.Fatalf("PackOrderBookBatch failed: %v", err)
+	}
+	header, gotBooks, gotVols, err := UnpackOrderBookBatch(data)
+	if err != nil {
+		t.Fatalf("UnpackOrderBookBatch failed: %v", err)
+	}
+	if header.Count != 2 {
+		t.Errorf("count = %d, want 2", header.Count)
+	}
+	for i := range books {
+		if *gotBooks[i] != books[i] {
+			t.Errorf("book %d = %+v, want %+v", i, *gotBooks[i], books[i])
+		}
+		for j := range volumes[i] {
+			if gotVols[i][j] != volumes[i][j] {
+				t.Errorf("volume[%d][%d] = %d, want %d", i, j, gotVols[i][j], volumes[i][j])
+			}
+		}
+	}
+}
+
+func TestUnpackOrderBookBatchOverrun(t *testing.T) {
+	books := []OrderBookBody{{TickerID: 1, FirstTick: 1, TickSize: 1, NumTicks: 4, Side: SideBuy}}
+	data, err := PackOrderBookBatch(books, [][]uint32{{1, 2, 3, 4}})
+	if err != nil {
+		t.Fatalf("PackOrderBookBatch failed: %v", err)
+	}
+	// Corrupt NumTicks so the volume array would overrun the buffer.
+	ByteOrder.PutUint16(data[HeaderSize+24:HeaderSize+26], 1000)
+	if _, _, _, err := UnpackOrderBookBatch(data); err == nil {
+		t.Error("expected error for overrunning NumTicks")
+	}
+	// Claim a second book that isn't there.
+	data[7] = 2
+	if _, _, _, err := UnpackOrderBookBatch(data); err == nil {
+		t.Error("expected error for missing second book")
+	}
+}
+
+func TestOrderBookLevelAccessors(t *testing.T) {
+	asks := OrderBookBody{FirstTick: 100.0, TickSize: 0.5, NumTicks: 4, Side: SideSell}
+	askVols := []uint32{10, 20, 30, 40}
+
+	price, volume, err := asks.Level(2, askVols)
+	if err != nil {
+		t.Fatalf("Level failed: %v", err)
+	}
+	if price != 101.0 || volume != 30 {
+		t.Errorf("ask level 2 = (%v, %d), want (101, 30)", price, volume)
+	}
+
+	bids := OrderBookBody{FirstTick: 99.5, TickSize: 0.5, NumTicks: 3, Side: SideBuy}
+	bidVols := []uint32{5, 6, 7}
+	price, volume, err = bids.Level(2, bidVols)
+	if err != nil {
+		t.Fatalf("Level failed: %v", err)
+	}
+	if price != 98.5 || volume != 7 {
+		t.Errorf("bid level 2 = (%v, %d), want (98.5, 7)", price, volume)
+	}
+
+	if _, _, err := asks.Level(4, askVols); err == nil {
+		t.Error("expected error for level beyond NumTicks")
+	}
+	if _, _, err := asks.Level(-1, askVols); err == nil {
+		t.Error("expected error for negative level")
+	}
+	if _, _, err := asks.Level(3, askVols[:2]); err == nil {
+		t.Error("expected error for level beyond volume slice")
+	}
+}
+
+func TestOrderBookIterate(t *testing.T) {
+	bids := OrderBookBody{FirstTick: 50, TickSize: 1, NumTicks: 3, Side: SideBuy}
+	var prices []float64
+	var total uint32
+	bids.Iterate([]uint32{1, 2, 3}, func(price float64, volume uint32) {
+		prices = append(prices, price)
+		total += volume
+	})
+	want := []float64{50, 49, 48} // bids walk descending from the best bid
+	for i := range want {
+		if prices[i] != want[i] {
+			t.Errorf("price[%d] = %v, want %v", i, prices[i], want[i])
+		}
+	}
+	if total != 6 {
+		t.Errorf("total volume = %d, want 6", total)
+	}
+}
+
+func TestPackOrderBookValidation(t *testing.T) {
+	book := OrderBookBody{NumTicks: 0}
+	if _, err := PackOrderBook(MitchHeader{}, book, nil); err == nil {
+		t.Error("expected error for zero NumTicks")
+	}
+
+	book.NumTicks = 10
+	if _, err := PackOrderBook(MitchHeader{}, book, make([]uint32, 5)); err == nil {
+		t.Error("expected error for volume length mismatch")
+	}
+}
+
+func TestBestLevel(t *testing.T) {
+	asks := OrderBookBody{FirstTick: 100, TickSize: 0.5, NumTicks: 4, Side: SideSell}
+	// The first two grid levels are empty placeholders.
+	price, volume, ok := asks.BestLevel([]uint32{0, 0, 7, 9})
+	if !ok || price != 101 || volume != 7 {
+		t.Errorf("ask top = (%v, %d, %v), want (101, 7, true)", price, volume, ok)
+	}
+
+	bids := OrderBookBody{FirstTick: 99.5, TickSize: 0.5, NumTicks: 3, Side: SideBuy}
+	price, volume, ok = bids.BestLevel([]uint32{4, 0, 2})
+	if !ok || price != 99.5 || volume != 4 {
+		t.Errorf("bid top = (%v, %d, %v), want (99.5, 4, true)", price, volume, ok)
+	}
+
+	if _, _, ok := asks.BestLevel([]uint32{0, 0, 0, 0}); ok {
+		t.Error("all-zero book must report ok=false")
+	}
+	empty := OrderBookBody{NumTicks: 0}
+	if _, _, ok := empty.BestLevel(nil); ok {
+		t.Error("empty book must report ok=false")
+	}
+}
+
+func TestMaxOrderBookLevels(t *testing.T) {
+	defer func(old int) { MaxOrderBookLevels = old }(MaxOrderBookLevels)
+	MaxOrderBookLevels = 4
+
+	book := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 0.5, NumTicks: 5, Side: SideSell}
+	frame, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{{1, 2, 3, 4, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := UnpackOrderBook(frame); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("UnpackOrderBook err = %v, want ErrMessageTooLarge", err)
+	}
+	if _, _, _, err := UnpackOrderBookBatch(frame); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("UnpackOrderBookBatch err = %v, want ErrMessageTooLarge", err)
+	}
+
+	// Raising the cap back accepts the same message.
+	MaxOrderBookLevels = 10000
+	if _, _, _, err := UnpackOrderBook(frame); err != nil {
+		t.Errorf("generous cap rejected a 5-level book: %v", err)
+	}
+}
+
+func TestUnpackOrderBookInto(t *testing.T) {
+	book := OrderBookBody{TickerID: 3, FirstTick: 50, TickSize: 0.25, NumTicks: 4, Side: SideBuy}
+	frame, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{{9, 8, 7, 6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded OrderBookBody
+	volumes := make([]uint32, 0, 16)
+	if err := UnpackOrderBookInto(frame, &decoded, &volumes); err != nil {
+		t.Fatalf("UnpackOrderBookInto: %v", err)
+	}
+	if decoded.TickerID != 3 || len(volumes) != 4 || volumes[3] != 6 {
+		t.Errorf("decoded = %+v volumes %v", decoded, volumes)
+	}
+
+	// A second decode must reuse the same backing array.
+	before := &volumes[:cap(volumes)][0]
+	if err := UnpackOrderBookInto(frame, &decoded, &volumes); err != nil {
+		t.Fatal(err)
+	}
+	if &volumes[:cap(volumes)][0] != before {
+		t.Error("decode with sufficient capacity reallocated the slice")
+	}
+
+	// Insufficient capacity grows the slice.
+	small := make([]uint32, 0, 1)
+	if err := UnpackOrderBookInto(frame, &decoded, &small); err != nil {
+		t.Fatal(err)
+	}
+	if len(small) != 4 {
+		t.Errorf("grown slice has %d entries, want 4", len(small))
+	}
+}
+
+func BenchmarkUnpackOrderBook(b *testing.B) {
+	book := OrderBookBody{TickerID: 3, FirstTick: 50, TickSize: 0.25, NumTicks: 64, Side: SideBuy}
+	frame, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{make([]uint32, 64)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := UnpackOrderBook(frame); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnpackOrderBookInto(b *testing.B) {
+	book := OrderBookBody{TickerID: 3, FirstTick: 50, TickSize: 0.25, NumTicks: 64, Side: SideBuy}
+	frame, err := PackOrderBookBatch([]OrderBookBody{book}, [][]uint32{make([]uint32, 64)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	var decoded OrderBookBody
+	volumes := make([]uint32, 0, 64)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := UnpackOrderBookInto(frame, &decoded, &volumes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Channel-based decode pipeline with backpressure.
+//
+// A consumer that parses inline couples its processing latency to the
+// reader; a pipeline decodes in its own goroutine and hands parsed
+// messages over a bounded channel. When the consumer lags, the channel
+// fills and the decoder stops reading, so backpressure propagates to the
+// transport (and on TCP, to the publisher) instead of buffering without
+// bound.
+
+package mitch
+
+import (
+	"context"
+	"io"
+)
+
+// NewPipeline decodes messages from r in a background goroutine, pushing
+// them onto a channel with capacity bufSize. Both channels close when the
+// reader ends or ctx is cancelled; a clean EOF closes them without an
+// error, any other decode failure is delivered on the error channel
+// first. The goroutine stops reading while the message channel is full.
+//
+// Cancelling ctx stops the pipeline between messages; a Decode blocked on
+// a read holds the goroutine until the reader yields, so callers owning a
+// net.Conn should also close it on cancel.
+func NewPipeline(ctx context.Context, r io.Reader, bufSize int) (<-chan DecodedMessage, <-chan error) {
+	messages := make(chan DecodedMessage, bufSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(messages)
+		defer close(errs)
+		decoder := NewDecoder(r)
+		for {
+			header, bodies, err := decoder.Decode()
+			if err != nil {
+				if err != io.EOF {
+					errs <- err
+				}
+				return
+			}
+			select {
+			case messages <- DecodedMessage{Header: header, Bodies: bodies}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return messages, errs
+}
@@ -0,0 +1,80 @@
+package mitch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestPipelineDeliversAndCloses(t *testing.T) {
+	var stream bytes.Buffer
+	for i := 0; i < 3; i++ {
+		frame, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: uint32(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.Write(frame)
+	}
+
+	messages, errs := NewPipeline(context.Background(), &stream, 1)
+	var got []uint32
+	for msg := range messages {
+		got = append(got, msg.Bodies[0].(*TradeBody).TradeID)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("clean EOF delivered error: %v", err)
+	}
+	if len(got) != 3 || got[2] != 2 {
+		t.Errorf("trades = %v, want [0 1 2]", got)
+	}
+}
+
+func TestPipelineSurfacesDecodeError(t *testing.T) {
+	// A truncated frame: header promising a body that never arrives.
+	frame, err := PackMessage(MsgTypeTrade, TradeBody{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, errs := NewPipeline(context.Background(), bytes.NewReader(frame[:HeaderSize+4]), 1)
+	for range messages {
+	}
+	if err := <-errs; !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestPipelineCancelStops(t *testing.T) {
+	var stream bytes.Buffer
+	for i := 0; i < 10; i++ {
+		frame, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: uint32(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.Write(frame)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// bufSize 0: the decoder blocks on the first handoff, so cancelling
+	// without ever receiving must still close the channels.
+	messages, errs := NewPipeline(ctx, &stream, 0)
+	cancel()
+
+	deadline := time.After(5 * time.Second)
+	for messages != nil || errs != nil {
+		select {
+		case _, ok := <-messages:
+			if !ok {
+				messages = nil
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+		case <-deadline:
+			t.Fatal("pipeline did not stop after cancel")
+		}
+	}
+}
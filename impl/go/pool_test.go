@@ -0,0 +1,69 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPackMessageIntoMatchesPackMessage(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 2, Side: SideBuy}
+
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if err := PackMessageInto(buf, MsgTypeTrade, trade); err != nil {
+		t.Fatalf("PackMessageInto: %v", err)
+	}
+	want, err := PackMessage(MsgTypeTrade, trade)
+	if err != nil {
+		t.Fatalf("PackMessage: %v", err)
+	}
+	// Timestamps differ between the two calls; compare everything else.
+	got := buf.Bytes()
+	if len(got) != len(want) {
+		t.Fatalf("packed %d bytes, want %d", len(got), len(want))
+	}
+	if got[0] != want[0] || got[7] != want[7] || !bytes.Equal(got[HeaderSize:], want[HeaderSize:]) {
+		t.Errorf("PackMessageInto output diverges from PackMessage")
+	}
+}
+
+func TestPackMessageIntoErrors(t *testing.T) {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if err := PackMessageInto(buf, MsgTypeTrade); err == nil {
+		t.Error("expected an error for zero bodies")
+	}
+}
+
+func TestBufferReuse(t *testing.T) {
+	buf := GetBuffer()
+	buf.WriteString("leftover")
+	PutBuffer(buf)
+	again := GetBuffer()
+	defer PutBuffer(again)
+	if again.Len() != 0 {
+		t.Errorf("pooled buffer not reset: %d bytes", again.Len())
+	}
+}
+
+func BenchmarkPackMessage(b *testing.B) {
+	trade := TradeBody{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 2}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := PackMessage(MsgTypeTrade, trade); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPackMessageInto(b *testing.B) {
+	trade := TradeBody{TickerID: 1, Price: 100.5, Quantity: 10, TradeID: 2}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := GetBuffer()
+		if err := PackMessageInto(buf, MsgTypeTrade, trade); err != nil {
+			b.Fatal(err)
+		}
+		PutBuffer(buf)
+	}
+}
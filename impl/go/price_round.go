@@ -0,0 +1,36 @@
+// Decimal price rounding for float encoding.
+//
+// Converting external decimal prices to float64 leaves artifacts like
+// 1.23449999999, and naive half-up rounding drifts systematically in one
+// direction over millions of prints. RoundPrice uses round-half-to-even
+// (banker's rounding), the same tie-breaking as IEEE 754, so a publisher
+// normalizing prices before packing matches the venue's published
+// decimals without bias.
+
+package mitch
+
+import "math"
+
+// RoundPrice rounds price to the given number of decimal places with
+// half-to-even tie breaking: 0.125 at two decimals rounds to 0.12, 0.135
+// to 0.14. Negative decimals round to powers of ten; NaN and infinities
+// pass through unchanged.
+func RoundPrice(price float64, decimals int) float64 {
+	if math.IsNaN(price) || math.IsInf(price, 0) {
+		return price
+	}
+	scale := math.Pow(10, float64(decimals))
+	return math.RoundToEven(price*scale) / scale
+}
+
+// RoundToPrecision rounds price to the instrument's registered decimal
+// precision, the packing-side companion to NormalizePrice: use this when
+// publishing decimal-quoted feeds, NormalizePrice when snapping to a tick
+// grid. Unknown instruments pass through unchanged.
+func (r *InstrumentRegistry) RoundToPrecision(id uint64, price float64) float64 {
+	meta, ok := r.Lookup(id)
+	if !ok {
+		return price
+	}
+	return RoundPrice(price, meta.Precision)
+}
@@ -0,0 +1,46 @@
+package mitch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundPrice(t *testing.T) {
+	tests := []struct {
+		price    float64
+		decimals int
+		want     float64
+	}{
+		{1.23449999999, 4, 1.2345},
+		{0.125, 2, 0.12}, // half rounds to even
+		{0.135, 2, 0.14},
+		{-0.125, 2, -0.12},
+		{1234.5, -1, 1230}, // negative decimals hit powers of ten
+		{2.5, 0, 2},
+		{3.5, 0, 4},
+	}
+	for _, tt := range tests {
+		if got := RoundPrice(tt.price, tt.decimals); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("RoundPrice(%v, %d) = %v, want %v", tt.price, tt.decimals, got, tt.want)
+		}
+	}
+
+	if got := RoundPrice(math.NaN(), 2); !math.IsNaN(got) {
+		t.Errorf("NaN must pass through, got %v", got)
+	}
+	if got := RoundPrice(math.Inf(1), 2); !math.IsInf(got, 1) {
+		t.Errorf("+Inf must pass through, got %v", got)
+	}
+}
+
+func TestRoundToPrecision(t *testing.T) {
+	r := NewInstrumentRegistry()
+	r.Register(1, InstrumentMeta{Precision: 2, TickSize: 0.01})
+
+	if got := r.RoundToPrecision(1, 99.98999999); math.Abs(got-99.99) > 1e-12 {
+		t.Errorf("RoundToPrecision = %v, want 99.99", got)
+	}
+	if got := r.RoundToPrecision(2, 1.23456); got != 1.23456 {
+		t.Errorf("unknown instrument must pass through, got %v", got)
+	}
+}
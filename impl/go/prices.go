@@ -0,0 +1,34 @@
+// Bulk price decoding for hot paths.
+//
+// Decoding millions of prices one field at a time pays a call and a
+// bounds check per value. DecodePrices converts a packed run of
+// big-endian float64s in a single tight loop — the slice re-slicing
+// pattern lets the compiler hoist the bounds checks, and the loop shape
+// is simple enough for the hardware to pipeline even without explicit
+// SIMD.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+)
+
+// DecodePrices reads len(out) consecutive big-endian float64s from raw
+// into the preallocated out slice. raw must hold at least len(out)*8
+// bytes; extra trailing bytes are ignored so callers can point it at a
+// larger batch region.
+func DecodePrices(raw []byte, out []float64) error {
+	need := len(out) * 8
+	if len(raw) < need {
+		return fmt.Errorf("mitch: %w: %d price bytes for %d outputs (need %d)", ErrShortBuffer, len(raw), len(out), need)
+	}
+	raw = raw[:need]
+	for i := range out {
+		chunk := raw[i*8 : i*8+8]
+		bits := uint64(chunk[0])<<56 | uint64(chunk[1])<<48 | uint64(chunk[2])<<40 | uint64(chunk[3])<<32 |
+			uint64(chunk[4])<<24 | uint64(chunk[5])<<16 | uint64(chunk[6])<<8 | uint64(chunk[7])
+		out[i] = math.Float64frombits(bits)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package mitch
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func packedPrices(values []float64) []byte {
+	raw := make([]byte, len(values)*8)
+	for i, v := range values {
+		ByteOrder.PutUint64(raw[i*8:], math.Float64bits(v))
+	}
+	return raw
+}
+
+func TestDecodePrices(t *testing.T) {
+	want := []float64{0, 1.5, -42.25, math.MaxFloat64, math.Inf(1)}
+	raw := packedPrices(want)
+
+	out := make([]float64, len(want))
+	if err := DecodePrices(raw, out); err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Errorf("out[%d] = %v, want %v", i, out[i], want[i])
+		}
+	}
+
+	// NaN bit patterns survive the round trip.
+	nanRaw := packedPrices([]float64{math.NaN()})
+	nanOut := make([]float64, 1)
+	if err := DecodePrices(nanRaw, nanOut); err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(nanOut[0]) {
+		t.Errorf("NaN decoded as %v", nanOut[0])
+	}
+
+	// Trailing bytes beyond len(out)*8 are ignored.
+	if err := DecodePrices(append(raw, 0xFF), out); err != nil {
+		t.Errorf("extra trailing byte: %v", err)
+	}
+}
+
+func TestDecodePricesShortBuffer(t *testing.T) {
+	out := make([]float64, 2)
+	if err := DecodePrices(make([]byte, 15), out); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("short raw = %v, want ErrShortBuffer", err)
+	}
+}
+
+func BenchmarkDecodePrices(b *testing.B) {
+	values := make([]float64, 1024)
+	for i := range values {
+		values[i] = float64(i) * 0.25
+	}
+	raw := packedPrices(values)
+	out := make([]float64, len(values))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := DecodePrices(raw, out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePricesPerField(b *testing.B) {
+	values := make([]float64, 1024)
+	for i := range values {
+		values[i] = float64(i) * 0.25
+	}
+	raw := packedPrices(values)
+	out := make([]float64, len(values))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range out {
+			out[j] = math.Float64frombits(ByteOrder.Uint64(raw[j*8:]))
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Protobuf conversion for gRPC interop.
+//
+// Downstream analytics services speak gRPC; these conversions map each body
+// type to and from the messages in model/pb. Timestamps on the MITCH wire
+// are 48-bit nanoseconds since midnight UTC with no date, so they convert
+// to protobuf Timestamps on the Unix epoch date, matching the JSON
+// encoding's convention.
+
+package mitch
+
+import (
+	"time"
+
+	"github.com/btr-supply/mitch/impl/go/model/pb"
+)
+
+// TimestampToProto converts a 48-bit nanoseconds-since-midnight timestamp
+// into a protobuf Timestamp on the Unix epoch date.
+func TimestampToProto(ts [6]byte) *pb.Timestamp {
+	nanos := ReadTimestamp48(ts)
+	return &pb.Timestamp{
+		Seconds: int64(nanos / uint64(time.Second)),
+		Nanos:   int32(nanos % uint64(time.Second)),
+	}
+}
+
+// TimestampFromProto converts a protobuf Timestamp back into a 48-bit
+// timestamp, keeping only the time of day.
+func TimestampFromProto(ts *pb.Timestamp) [6]byte {
+	if ts == nil {
+		return [6]byte{}
+	}
+	nanos := uint64(ts.Seconds)*uint64(time.Second) + uint64(ts.Nanos)
+	return WriteTimestamp48(nanos % nanosPerDay)
+}
+
+// ToProto converts the trade into its protobuf message.
+func (t *TradeBody) ToProto() *pb.Trade {
+	return &pb.Trade{
+		TickerID: t.TickerID,
+		Price:    t.Price,
+		Quantity: t.Quantity,
+		TradeID:  t.TradeID,
+		Side:     uint32(t.Side),
+	}
+}
+
+// TradeFromProto converts a protobuf trade back into a TradeBody.
+func TradeFromProto(p *pb.Trade) TradeBody {
+	return TradeBody{
+		TickerID: p.TickerID,
+		Price:    p.Price,
+		Quantity: p.Quantity,
+		TradeID:  p.TradeID,
+		Side:     uint8(p.Side),
+	}
+}
+
+// ToProto converts the order into its protobuf message, splitting
+// TypeAndSide and leaving Expiry nil for GTC orders.
+func (o *OrderBody) ToProto() *pb.Order {
+	order := &pb.Order{
+		TickerID:  o.TickerID,
+		OrderID:   o.OrderID,
+		Price:     o.Price,
+		Quantity:  o.Quantity,
+		OrderType: uint32(o.TypeAndSide >> 1),
+		Side:      uint32(o.TypeAndSide & 1),
+	}
+	if ReadTimestamp48(o.Expiry) != 0 {
+		order.Expiry = TimestampToProto(o.Expiry)
+	}
+	return order
+}
+
+// OrderFromProto converts a protobuf order back into an OrderBody.
+func OrderFromProto(p *pb.Order) OrderBody {
+	return OrderBody{
+		TickerID:    p.TickerID,
+		OrderID:     p.OrderID,
+		Price:       p.Price,
+		Quantity:    p.Quantity,
+		TypeAndSide: uint8(p.OrderType)<<1 | uint8(p.Side)&1,
+		Expiry:      TimestampFromProto(p.Expiry),
+	}
+}
+
+// ToProto converts the ticker into its protobuf message.
+func (t *TickerBody) ToProto() *pb.Ticker {
+	return &pb.Ticker{
+		TickerID:  t.TickerID,
+		BidPrice:  t.BidPrice,
+		AskPrice:  t.AskPrice,
+		BidVolume: t.BidVolume,
+		AskVolume: t.AskVolume,
+	}
+}
+
+// TickerFromProto converts a protobuf ticker back into a TickerBody.
+func TickerFromProto(p *pb.Ticker) TickerBody {
+	return TickerBody{
+		TickerID:  p.TickerID,
+		BidPrice:  p.BidPrice,
+		AskPrice:  p.AskPrice,
+		BidVolume: p.BidVolume,
+		AskVolume: p.AskVolume,
+	}
+}
+
+// ToProto converts the book header and its volumes into one protobuf
+// message. The proto carries the volumes inline, so NumTicks is implied by
+// len(Volumes).
+func (b *OrderBookBody) ToProto(volumes []uint32) *pb.OrderBook {
+	return &pb.OrderBook{
+		TickerID:  b.TickerID,
+		FirstTick: b.FirstTick,
+		TickSize:  b.TickSize,
+		Side:      uint32(b.Side),
+		Volumes:   append([]uint32(nil), volumes...),
+	}
+}
+
+// OrderBookFromProto converts a protobuf book back into a book header and
+// volume slice.
+func OrderBookFromProto(p *pb.OrderBook) (OrderBookBody, []uint32) {
+	book := OrderBookBody{
+		TickerID:  p.TickerID,
+		FirstTick: p.FirstTick,
+		TickSize:  p.TickSize,
+		NumTicks:  uint16(len(p.Volumes)),
+		Side:      Side(p.Side),
+	}
+	return book, append([]uint32(nil), p.Volumes...)
+}
@@ -0,0 +1,73 @@
+package mitch
+
+import "testing"
+
+func TestTradeProtoRoundTrip(t *testing.T) {
+	trade := TradeBody{TickerID: 42, Price: 101.5, Quantity: 1000, TradeID: 7, Side: SideSell}
+	if got := TradeFromProto(trade.ToProto()); got != trade {
+		t.Errorf("round trip = %+v, want %+v", got, trade)
+	}
+}
+
+func TestOrderProtoRoundTrip(t *testing.T) {
+	order := OrderBody{
+		TickerID:    9,
+		OrderID:     33,
+		Price:       55.25,
+		Quantity:    100,
+		TypeAndSide: OrderTypeStop<<1 | SideBuy,
+		Expiry:      WriteTimestamp48(12 * 3600 * 1_000_000_000),
+	}
+	got := OrderFromProto(order.ToProto())
+	if got != order {
+		t.Errorf("round trip = %+v, want %+v", got, order)
+	}
+
+	gtc := OrderBody{TickerID: 1, TypeAndSide: OrderTypeLimit << 1}
+	p := gtc.ToProto()
+	if p.Expiry != nil {
+		t.Errorf("GTC order should convert with nil Expiry, got %+v", p.Expiry)
+	}
+	if got := OrderFromProto(p); got != gtc {
+		t.Errorf("GTC round trip = %+v, want %+v", got, gtc)
+	}
+}
+
+func TestTickerProtoRoundTrip(t *testing.T) {
+	ticker := TickerBody{TickerID: 5, BidPrice: 1.1, AskPrice: 1.2, BidVolume: 10, AskVolume: 20}
+	if got := TickerFromProto(ticker.ToProto()); got != ticker {
+		t.Errorf("round trip = %+v, want %+v", got, ticker)
+	}
+}
+
+func TestOrderBookProtoRoundTrip(t *testing.T) {
+	book := OrderBookBody{TickerID: 2, FirstTick: 100, TickSize: 0.25, NumTicks: 3, Side: SideSell}
+	volumes := []uint32{5, 0, 7}
+
+	gotBook, gotVolumes := OrderBookFromProto(book.ToProto(volumes))
+	if gotBook != book {
+		t.Errorf("book round trip = %+v, want %+v", gotBook, book)
+	}
+	if len(gotVolumes) != len(volumes) {
+		t.Fatalf("got %d volumes, want %d", len(gotVolumes), len(volumes))
+	}
+	for i := range volumes {
+		if gotVolumes[i] != volumes[i] {
+			t.Errorf("volume %d = %d, want %d", i, gotVolumes[i], volumes[i])
+		}
+	}
+}
+
+func TestTimestampProtoMapping(t *testing.T) {
+	const nanos = 13*3600*1_000_000_000 + 500 // 13:00:00.0000005
+	p := TimestampToProto(WriteTimestamp48(nanos))
+	if p.Seconds != 13*3600 || p.Nanos != 500 {
+		t.Errorf("proto timestamp = %+v", p)
+	}
+	if got := ReadTimestamp48(TimestampFromProto(p)); got != nanos {
+		t.Errorf("round trip = %d, want %d", got, nanos)
+	}
+	if got := TimestampFromProto(nil); ReadTimestamp48(got) != 0 {
+		t.Errorf("nil proto timestamp should map to zero")
+	}
+}
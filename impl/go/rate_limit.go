@@ -0,0 +1,132 @@
+// Token-bucket rate limiting for publishers.
+//
+// Downstream brokers often cap messages per second; exceeding the cap gets
+// a feed throttled or disconnected. RateLimitedPublisher meters publishes
+// through a token bucket refilled lazily from the wall clock, so there is
+// no background goroutine: a blocked Publish sleeps on a timer that Close
+// interrupts, and an idle limiter consumes nothing.
+
+package mitch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Publisher is anything that ships one packed message downstream.
+// MulticastPublisher satisfies it directly.
+type Publisher interface {
+	Publish(data []byte) error
+}
+
+// RateLimitedPublisher wraps a Publisher with a token bucket. In blocking
+// mode Publish waits for capacity; otherwise it returns ErrRateLimited
+// immediately. The bucket holds up to one second of tokens, so a quiet
+// feed can burst briefly before settling at the configured rate. Safe for
+// concurrent use.
+type RateLimitedPublisher struct {
+	next        Publisher
+	perSecond   float64
+	block       bool
+	countBodies bool
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	done   chan struct{}
+	closed bool
+}
+
+// NewRateLimitedPublisher wraps next with a limit of perSecond messages
+// per second. With block set, Publish waits for capacity; otherwise it
+// fails fast with ErrRateLimited. A non-positive rate disables limiting
+// entirely — mirroring Decoder.MaxBodyBytes — rather than letting the
+// bucket math divide by zero and spin a blocked Publish.
+func NewRateLimitedPublisher(next Publisher, perSecond float64, block bool) *RateLimitedPublisher {
+	return &RateLimitedPublisher{
+		next:      next,
+		perSecond: perSecond,
+		block:     block,
+		tokens:    perSecond,
+		last:      time.Now(),
+		done:      make(chan struct{}),
+	}
+}
+
+// CountBodies makes each publish cost the message's body count instead of
+// a flat 1, so a 255-trade batch draws 255 tokens and the limit meters
+// bodies per second. Off by default.
+func (p *RateLimitedPublisher) CountBodies(enable bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.countBodies = enable
+}
+
+// Publish forwards data to the wrapped publisher once the bucket has
+// capacity. After Close it always fails.
+func (p *RateLimitedPublisher) Publish(data []byte) error {
+	cost := 1.0
+	p.mu.Lock()
+	if p.perSecond <= 0 {
+		closed := p.closed
+		p.mu.Unlock()
+		if closed {
+			return fmt.Errorf("mitch: rate limiter is closed")
+		}
+		return p.next.Publish(data)
+	}
+	if p.countBodies && len(data) >= HeaderSize {
+		cost = float64(data[7])
+	}
+	for {
+		if p.closed {
+			p.mu.Unlock()
+			return fmt.Errorf("mitch: rate limiter is closed")
+		}
+		p.refillLocked()
+		if p.tokens >= cost {
+			p.tokens -= cost
+			p.mu.Unlock()
+			return p.next.Publish(data)
+		}
+		if !p.block {
+			p.mu.Unlock()
+			return fmt.Errorf("mitch: %w: need %.0f token(s)", ErrRateLimited, cost)
+		}
+		wait := time.Duration((cost - p.tokens) / p.perSecond * float64(time.Second))
+		p.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-p.done:
+			timer.Stop()
+			return fmt.Errorf("mitch: rate limiter is closed")
+		}
+		p.mu.Lock()
+	}
+}
+
+// Close releases any Publish blocked on capacity and fails all future
+// publishes. It does not close the wrapped publisher.
+func (p *RateLimitedPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	return nil
+}
+
+// refillLocked credits tokens for the time elapsed since the last refill,
+// capped at one second's worth.
+func (p *RateLimitedPublisher) refillLocked() {
+	now := time.Now()
+	p.tokens += now.Sub(p.last).Seconds() * p.perSecond
+	if p.tokens > p.perSecond {
+		p.tokens = p.perSecond
+	}
+	p.last = now
+}
@@ -0,0 +1,138 @@
+package mitch
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingPublisher records published frames.
+type countingPublisher struct {
+	mu     sync.Mutex
+	frames int
+}
+
+func (p *countingPublisher) Publish([]byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.frames++
+	return nil
+}
+
+func (p *countingPublisher) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.frames
+}
+
+func TestRateLimitedPublisherFailFast(t *testing.T) {
+	var sink countingPublisher
+	limited := NewRateLimitedPublisher(&sink, 2, false)
+	defer limited.Close()
+
+	frame, _ := PackHeartbeat(1)
+	// The bucket starts full with one second of tokens (2 here).
+	if err := limited.Publish(frame); err != nil {
+		t.Fatalf("publish 1: %v", err)
+	}
+	if err := limited.Publish(frame); err != nil {
+		t.Fatalf("publish 2: %v", err)
+	}
+	if err := limited.Publish(frame); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("publish 3: err = %v, want ErrRateLimited", err)
+	}
+	if sink.count() != 2 {
+		t.Errorf("downstream got %d frames, want 2", sink.count())
+	}
+}
+
+func TestRateLimitedPublisherBlocks(t *testing.T) {
+	var sink countingPublisher
+	limited := NewRateLimitedPublisher(&sink, 50, true)
+	defer limited.Close()
+
+	frame, _ := PackHeartbeat(1)
+	start := time.Now()
+	// 60 messages at 50/s with a 50-token burst: ~10 must wait ~200ms total.
+	for i := 0; i < 60; i++ {
+		if err := limited.Publish(frame); err != nil {
+			t.Fatalf("publish %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("60 publishes at 50/s took %v, expected blocking", elapsed)
+	}
+	if sink.count() != 60 {
+		t.Errorf("downstream got %d frames, want 60", sink.count())
+	}
+}
+
+func TestRateLimitedPublisherCountBodies(t *testing.T) {
+	var sink countingPublisher
+	limited := NewRateLimitedPublisher(&sink, 100, false)
+	defer limited.Close()
+	limited.CountBodies(true)
+
+	bodies := make([]interface{}, 100)
+	for i := range bodies {
+		bodies[i] = TradeBody{}
+	}
+	batch, err := PackMessage(MsgTypeTrade, bodies...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := limited.Publish(batch); err != nil {
+		t.Fatalf("first batch: %v", err)
+	}
+	// The batch drained all 100 tokens; the next one must be limited.
+	if err := limited.Publish(batch); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("second batch: err = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRateLimitedPublisherCloseUnblocks(t *testing.T) {
+	var sink countingPublisher
+	limited := NewRateLimitedPublisher(&sink, 0.001, true)
+
+	frame, _ := PackHeartbeat(1)
+	errs := make(chan error, 1)
+	go func() {
+		limited.Publish(frame) // drains the fractional bucket
+		errs <- limited.Publish(frame)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	limited.Close()
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("publish after Close must error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not unblock Publish")
+	}
+}
+
+func TestRateLimitedPublisherUnlimited(t *testing.T) {
+	var sink countingPublisher
+	// A non-positive rate disables limiting: every publish passes straight
+	// through with no bucket wait, even in blocking mode.
+	limited := NewRateLimitedPublisher(&sink, 0, true)
+
+	frame, _ := PackHeartbeat(1)
+	for i := 0; i < 100; i++ {
+		if err := limited.Publish(frame); err != nil {
+			t.Fatalf("publish %d: %v", i, err)
+		}
+	}
+	if sink.count() != 100 {
+		t.Errorf("downstream got %d frames, want 100", sink.count())
+	}
+
+	// Close still fails subsequent publishes.
+	limited.Close()
+	if err := limited.Publish(frame); err == nil {
+		t.Error("publish after Close succeeded")
+	}
+}
@@ -0,0 +1,75 @@
+// Symbol to TickerID registry.
+//
+// Every body carries a uint64 TickerID but humans and upstream feeds think
+// in symbols like "EURUSD". The registry lets a forwarder translate broker
+// symbols into IDs on ingest and back on display.
+
+package mitch
+
+import "sync"
+
+// SymbolRegistry is a concurrent-safe bidirectional map between symbols
+// and TickerIDs. IDs are assigned sequentially on first registration, so a
+// symbol maps to the same ID for the lifetime of the process.
+type SymbolRegistry struct {
+	mu      sync.RWMutex
+	byName  map[string]uint64
+	bySymID map[uint64]string
+	nextID  uint64
+}
+
+// NewSymbolRegistry returns an empty registry. IDs start at 1 so the zero
+// TickerID stays available as a "not registered" sentinel.
+func NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{
+		byName:  make(map[string]uint64),
+		bySymID: make(map[uint64]string),
+		nextID:  1,
+	}
+}
+
+// Register returns the ID for symbol, assigning a new one on first use.
+// Registering the same symbol again always returns the same ID.
+func (r *SymbolRegistry) Register(symbol string) uint64 {
+	r.mu.RLock()
+	id, ok := r.byName[symbol]
+	r.mu.RUnlock()
+	if ok {
+		return id
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.byName[symbol]; ok {
+		return id
+	}
+	id = r.nextID
+	r.nextID++
+	r.byName[symbol] = id
+	r.bySymID[id] = symbol
+	return id
+}
+
+// Lookup returns the symbol registered under id.
+func (r *SymbolRegistry) Lookup(id uint64) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbol, ok := r.bySymID[id]
+	return symbol, ok
+}
+
+// ID returns the ID for an already-registered symbol without registering
+// it.
+func (r *SymbolRegistry) ID(symbol string) (uint64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.byName[symbol]
+	return id, ok
+}
+
+// Len returns the number of registered symbols.
+func (r *SymbolRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.byName)
+}
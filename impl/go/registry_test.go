@@ -0,0 +1,64 @@
+package mitch
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSymbolRegistryIdempotent(t *testing.T) {
+	r := NewSymbolRegistry()
+	id1 := r.Register("EURUSD")
+	id2 := r.Register("GBPUSD")
+	if id1 == id2 {
+		t.Fatalf("distinct symbols share ID %d", id1)
+	}
+	if got := r.Register("EURUSD"); got != id1 {
+		t.Errorf("re-register EURUSD = %d, want %d", got, id1)
+	}
+
+	if symbol, ok := r.Lookup(id1); !ok || symbol != "EURUSD" {
+		t.Errorf("Lookup(%d) = %q, %v", id1, symbol, ok)
+	}
+	if id, ok := r.ID("GBPUSD"); !ok || id != id2 {
+		t.Errorf("ID(GBPUSD) = %d, %v", id, ok)
+	}
+	if _, ok := r.ID("UNKNOWN"); ok {
+		t.Error("ID returned ok for unregistered symbol")
+	}
+	if _, ok := r.Lookup(999); ok {
+		t.Error("Lookup returned ok for unknown ID")
+	}
+}
+
+func TestSymbolRegistryConcurrent(t *testing.T) {
+	r := NewSymbolRegistry()
+	symbols := []string{"A", "B", "C", "D"}
+
+	var wg sync.WaitGroup
+	ids := make([][]uint64, 8)
+	for g := 0; g < 8; g++ {
+		g := g
+		ids[g] = make([]uint64, len(symbols))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				for s, symbol := range symbols {
+					ids[g][s] = r.Register(symbol)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r.Len() != len(symbols) {
+		t.Errorf("registry size = %d, want %d", r.Len(), len(symbols))
+	}
+	for g := 1; g < 8; g++ {
+		for s := range symbols {
+			if ids[g][s] != ids[0][s] {
+				t.Errorf("goroutine %d got ID %d for %q, goroutine 0 got %d", g, ids[g][s], symbols[s], ids[0][s])
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Deterministic wire output from reused body structs.
+//
+// Bodies are plain structs that callers pool and reuse, and nothing ever
+// zeroed their padding: a struct recycled across messages could leak
+// stale bytes onto the wire, breaking byte-exact comparisons and leaking
+// data between messages. Two guarantees fix that. Every body type has a
+// Reset that zeroes all fields including padding, and the pack paths
+// write padding defensively — reserved flag bits (the synthetic-trade
+// marker, the book's snapshot/encoding flags and pair count) survive,
+// every other padding bit goes out as zero regardless of what the struct
+// holds.
+
+package mitch
+
+// Reset zeroes the trade, padding included, making the struct safe to
+// reuse for a new message.
+func (t *TradeBody) Reset() { *t = TradeBody{} }
+
+// Reset zeroes the order, padding included.
+func (o *OrderBody) Reset() { *o = OrderBody{} }
+
+// Reset zeroes the ticker.
+func (t *TickerBody) Reset() { *t = TickerBody{} }
+
+// Reset zeroes the book header, padding and flags included.
+func (b *OrderBookBody) Reset() { *b = OrderBookBody{} }
+
+// Reset zeroes the heartbeat, padding included.
+func (h *HeartbeatBody) Reset() { *h = HeartbeatBody{} }
+
+// Reset zeroes the checksum body, padding included.
+func (b *BookChecksumBody) Reset() { *b = BookChecksumBody{} }
+
+// sanitizedTradePadding clears every non-reserved padding bit; only the
+// synthetic-trade flag survives onto the wire.
+func sanitizedTradePadding(p [7]byte) [7]byte {
+	return [7]byte{p[0] & synthTradeFlag}
+}
+
+// sanitizedBookPadding keeps the reserved bytes — snapshot flag, volume
+// encoding flag and pair count — and clears the spare one.
+func sanitizedBookPadding(p [5]byte) [5]byte {
+	return [5]byte{p[0], p[1], p[2], p[3], 0}
+}
+
+// sanitizeBody returns a copy of the body with its padding sanitized, for
+// the reflection-based pack path. Unrecognized types pass through and hit
+// the usual unknown-type handling downstream.
+func sanitizeBody(body interface{}) interface{} {
+	switch v := body.(type) {
+	case TradeBody:
+		v.Padding = sanitizedTradePadding(v.Padding)
+		return v
+	case *TradeBody:
+		c := *v
+		c.Padding = sanitizedTradePadding(c.Padding)
+		return c
+	case OrderBody:
+		v.Padding = [1]byte{}
+		return v
+	case *OrderBody:
+		c := *v
+		c.Padding = [1]byte{}
+		return c
+	case OrderBookBody:
+		v.Padding = sanitizedBookPadding(v.Padding)
+		return v
+	case *OrderBookBody:
+		c := *v
+		c.Padding = sanitizedBookPadding(c.Padding)
+		return c
+	}
+	return body
+}
@@ -0,0 +1,102 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBodyReset(t *testing.T) {
+	trade := TradeBody{TickerID: 1, Price: 2, Quantity: 3, TradeID: 4, Side: SideSell, Padding: [7]byte{1, 2, 3, 4, 5, 6, 7}}
+	trade.Reset()
+	if trade != (TradeBody{}) {
+		t.Errorf("TradeBody after Reset = %+v", trade)
+	}
+
+	order := OrderBody{TickerID: 1, OrderID: 2, Expiry: [6]byte{1}, Padding: [1]byte{0xFF}}
+	order.Reset()
+	if order != (OrderBody{}) {
+		t.Errorf("OrderBody after Reset = %+v", order)
+	}
+
+	book := OrderBookBody{TickerID: 1, NumTicks: 5, Padding: [5]byte{1, 2, 3, 4, 5}}
+	book.Reset()
+	if book != (OrderBookBody{}) {
+		t.Errorf("OrderBookBody after Reset = %+v", book)
+	}
+
+	heartbeat := HeartbeatBody{Sequence: 9, Padding: [2]byte{1, 2}}
+	heartbeat.Reset()
+	if heartbeat != (HeartbeatBody{}) {
+		t.Errorf("HeartbeatBody after Reset = %+v", heartbeat)
+	}
+}
+
+func TestPackPathZeroesStalePadding(t *testing.T) {
+	// Two trades identical except for padding junk must encode
+	// identically (modulo the reserved synthetic flag, which survives).
+	clean := TradeBody{TickerID: 7, Price: 1.5, Quantity: 2, TradeID: 3, Side: SideBuy}
+	dirty := clean
+	dirty.Padding = [7]byte{0xFE, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+	cleanBytes, err := clean.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirtyBytes, err := dirty.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cleanBytes, dirtyBytes) {
+		t.Errorf("padding junk leaked through MarshalBinary:\nclean %x\ndirty %x", cleanBytes, dirtyBytes)
+	}
+
+	if !bytes.Equal(AppendTrade(nil, clean), AppendTrade(nil, dirty)) {
+		t.Error("padding junk leaked through AppendTrade")
+	}
+
+	cleanMsg, err := PackMessage(MsgTypeTrade, clean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirtyMsg, err := PackMessage(MsgTypeTrade, &dirty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cleanMsg[HeaderSize:], dirtyMsg[HeaderSize:]) {
+		t.Error("padding junk leaked through PackMessage")
+	}
+}
+
+func TestAppendTradeReusedBufferLeaksNothing(t *testing.T) {
+	// A recycled buffer full of junk must not show through the padding
+	// region of a freshly appended trade.
+	buf := bytes.Repeat([]byte{0xAB}, BodySizeFixed)
+	buf = buf[:0]
+	buf = AppendTrade(buf, TradeBody{TickerID: 1, Side: SideSell})
+	for i := 25; i < 32; i++ {
+		if buf[i] != 0 {
+			t.Fatalf("padding byte %d = 0x%02X, want 0", i, buf[i])
+		}
+	}
+}
+
+func TestSanitizePreservesReservedFlags(t *testing.T) {
+	ticker := TickerBody{TickerID: 1, BidPrice: 10, AskPrice: 11}
+	synthetic := SynthTrade(&ticker, 5)
+	synthetic.Padding[1] = 0xFF // junk next to the flag
+
+	data, err := synthetic.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TradeBody
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsSynthetic() {
+		t.Error("sanitizing dropped the synthetic flag")
+	}
+	if decoded.Padding[1] != 0 {
+		t.Error("junk padding survived sanitizing")
+	}
+}
@@ -0,0 +1,48 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSequencedDecoderGapDetection(t *testing.T) {
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1, TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stream bytes.Buffer
+	stream.Write(PackSequenced(10, message))
+	stream.Write(PackSequenced(11, message))
+	stream.Write(PackSequenced(14, message)) // 12 and 13 dropped
+	stream.Write(PackSequenced(15, message))
+
+	d := NewSequencedDecoder(&stream)
+
+	seq, _, _, err := d.Decode()
+	if err != nil || seq != 10 {
+		t.Fatalf("first decode: seq=%d err=%v", seq, err)
+	}
+	if _, _, _, err := d.Decode(); err != nil {
+		t.Fatalf("second decode: %v", err)
+	}
+
+	seq, header, bodies, err := d.Decode()
+	var gap *GapError
+	if !errors.As(err, &gap) {
+		t.Fatalf("third decode err = %v, want *GapError", err)
+	}
+	if gap.Expected != 12 || gap.Got != 14 {
+		t.Errorf("gap = %+v, want Expected=12 Got=14", gap)
+	}
+	// The out-of-sequence message must still be delivered.
+	if seq != 14 || header == nil || len(bodies) != 1 {
+		t.Errorf("gapped message not delivered: seq=%d header=%v bodies=%d", seq, header, len(bodies))
+	}
+
+	// Tracking resumes from the received number.
+	if _, _, _, err := d.Decode(); err != nil {
+		t.Errorf("fourth decode after gap: %v", err)
+	}
+}
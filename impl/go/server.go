@@ -0,0 +1,116 @@
+// TCP server scaffolding for MITCH feeds.
+//
+// Standing up a feed endpoint otherwise means hand-rolling the accept
+// loop, per-connection goroutines, accept-error backoff and shutdown
+// plumbing every time. Server owns that: Serve runs a bare accept loop
+// for custom connection handling, and ListenAndServe layers a Decoder
+// plus Handler dispatch on each connection for the common consume case.
+
+package mitch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Server accepts MITCH connections and runs a handler per connection.
+// The zero value is ready to use; one Server can serve many listeners.
+type Server struct {
+	wg  sync.WaitGroup
+	log Logger
+}
+
+// SetLogger routes serve diagnostics — accept errors and dropped
+// consumers — to l. Set it before Serve; the default is a no-op logger.
+func (s *Server) SetLogger(l Logger) {
+	s.log = orNop(l)
+}
+
+// logger returns the configured logger or the no-op default.
+func (s *Server) logger() Logger {
+	return orNop(s.log)
+}
+
+// Serve accepts connections from l until ctx is cancelled, running
+// handler in its own goroutine per connection. Transient accept errors
+// back off exponentially from 5ms to 1s instead of spinning; cancellation
+// closes the listener, waits for the in-flight handlers and returns nil.
+// Handlers own their connection and must close it.
+func (s *Server) Serve(ctx context.Context, l net.Listener, handler func(conn net.Conn)) error {
+	// Unblock Accept when the context goes.
+	stop := context.AfterFunc(ctx, func() { l.Close() })
+	defer stop()
+
+	backoff := 5 * time.Millisecond
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				s.wg.Wait()
+				return nil
+			}
+			var nerr net.Error
+			if errors.As(err, &nerr) && nerr.Timeout() {
+				s.logger().Debugf("mitch: accept timed out (%v), backing off %v", err, backoff)
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					s.wg.Wait()
+					return nil
+				}
+				if backoff *= 2; backoff > time.Second {
+					backoff = time.Second
+				}
+				continue
+			}
+			s.logger().Errorf("mitch: accept failed: %v", err)
+			s.wg.Wait()
+			return err
+		}
+		backoff = 5 * time.Millisecond
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			handler(conn)
+		}()
+	}
+}
+
+// ListenAndServe listens on the TCP address and decodes each accepted
+// connection's stream, dispatching every message's bodies to h. A
+// connection whose stream errors is closed and dropped; the server keeps
+// serving the rest. It returns when ctx is cancelled or the listen fails.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, h Handler) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.serveDecoded(ctx, l, h)
+}
+
+// serveDecoded runs the Decoder-plus-dispatch handler on every accepted
+// connection.
+func (s *Server) serveDecoded(ctx context.Context, l net.Listener, h Handler) error {
+	return s.Serve(ctx, l, func(conn net.Conn) {
+		defer conn.Close()
+		// Close the conn on cancel so a blocked Decode unwinds.
+		stop := context.AfterFunc(ctx, func() { conn.Close() })
+		defer stop()
+
+		decoder := NewDecoder(conn)
+		for {
+			header, bodies, err := decoder.Decode()
+			if err != nil {
+				if ctx.Err() == nil && err != io.EOF {
+					s.logger().Errorf("mitch: dropping consumer %s: %v", conn.RemoteAddr(), err)
+				}
+				return
+			}
+			routeBodies(header, bodies, h)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package mitch
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingServerHandler struct {
+	NopHandler
+	mu     sync.Mutex
+	trades int
+}
+
+func (h *countingServerHandler) OnTrade(*MitchHeader, *TradeBody) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.trades++
+}
+
+func (h *countingServerHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.trades
+}
+
+func TestServerDispatchesToHandler(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		server  Server
+		handler countingServerHandler
+	)
+	served := make(chan error, 1)
+	go func() { served <- server.serveDecoded(ctx, l, &handler) }()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1}, TradeBody{TradeID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := MitchSendTCP(conn, frame); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for handler.count() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("handler saw %d trades, want 2", handler.count())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve returned %v after cancel, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+}
+
+func TestServerServeStopsOnCancel(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var server Server
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(ctx, l, func(conn net.Conn) { conn.Close() })
+	}()
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not stop")
+	}
+}
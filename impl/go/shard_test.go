@@ -0,0 +1,83 @@
+package mitch
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"testing"
+)
+
+func TestSharderStable(t *testing.T) {
+	s := NewSharder(8)
+
+	// The hash must match 64-bit FNV-1a over the big-endian ID bytes, so
+	// shard assignments survive process restarts and rebuilds.
+	for _, id := range []uint64{0, 1, 0xDEADBEEF, 1<<63 + 12345} {
+		h := fnv.New64a()
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], id)
+		h.Write(buf[:])
+		want := int(h.Sum64() % 8)
+		if got := s.Shard(id); got != want {
+			t.Errorf("Shard(%d) = %d, want %d", id, got, want)
+		}
+		if s.Shard(id) != s.Shard(id) {
+			t.Errorf("Shard(%d) not deterministic", id)
+		}
+	}
+}
+
+func TestSharderBounds(t *testing.T) {
+	s := NewSharder(5)
+	for id := uint64(0); id < 1000; id++ {
+		if got := s.Shard(id); got < 0 || got >= 5 {
+			t.Fatalf("Shard(%d) = %d, out of [0, 5)", id, got)
+		}
+	}
+
+	// Degenerate shard counts clamp to one shard.
+	if got := NewSharder(0).Shards(); got != 1 {
+		t.Errorf("NewSharder(0).Shards() = %d, want 1", got)
+	}
+	if got := NewSharder(-3).Shard(42); got != 0 {
+		t.Errorf("single-shard Shard = %d, want 0", got)
+	}
+}
+
+func TestSharderDistribution(t *testing.T) {
+	const shards, ids = 8, 10000
+	s := NewSharder(shards)
+
+	counts := make([]int, shards)
+	for id := uint64(0); id < ids; id++ {
+		counts[s.Shard(id)]++
+	}
+	// Sequential IDs — the common symbol-numbering scheme — should spread
+	// roughly evenly: no shard far from the ideal ids/shards load.
+	for shard, n := range counts {
+		if n < ids/shards/2 || n > ids/shards*2 {
+			t.Errorf("shard %d holds %d of %d IDs, want near %d", shard, n, ids, ids/shards)
+		}
+	}
+}
+
+func TestSharderShardBody(t *testing.T) {
+	s := NewSharder(16)
+	const id = uint64(0xCAFEBABE)
+	want := s.Shard(id)
+
+	bodies := []interface{}{
+		&TradeBody{TickerID: id},
+		&OrderBody{TickerID: id},
+		&TickerBody{TickerID: id},
+		&OrderBookEntry{Body: &OrderBookBody{TickerID: id}},
+		&BookChecksumBody{TickerID: id},
+	}
+	for _, body := range bodies {
+		if got := s.ShardBody(body); got != want {
+			t.Errorf("ShardBody(%T) = %d, want %d", body, got, want)
+		}
+	}
+	if got := s.ShardBody(&HeartbeatBody{}); got != 0 {
+		t.Errorf("ShardBody(heartbeat) = %d, want 0", got)
+	}
+}
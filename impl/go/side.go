@@ -0,0 +1,40 @@
+// Typed order book sides.
+//
+// OrderBookBody.Side was a bare uint8 with 0 for bids and 1 for asks,
+// and the magic numbers have already bitten: the trade-side constants
+// SideBuy/SideSell share the same values, so passing the wrong pair
+// compiles and silently mislabels a book. A named Side type makes the
+// field self-describing, gives the values a String, and lets validation
+// reject anything outside the two legal sides.
+
+package mitch
+
+import "fmt"
+
+// Side identifies which side of the book an OrderBookBody carries.
+type Side uint8
+
+const (
+	// BookSideBid marks a book of buy interest, levels descending from
+	// the best (highest) bid.
+	BookSideBid Side = 0
+	// BookSideAsk marks a book of sell interest, levels ascending from
+	// the best (lowest) ask.
+	BookSideAsk Side = 1
+)
+
+// Valid reports whether the side is one of the two legal values.
+func (s Side) Valid() bool {
+	return s == BookSideBid || s == BookSideAsk
+}
+
+// String returns "Bid", "Ask", or the raw value for anything illegal.
+func (s Side) String() string {
+	switch s {
+	case BookSideBid:
+		return "Bid"
+	case BookSideAsk:
+		return "Ask"
+	}
+	return fmt.Sprintf("Side(%d)", uint8(s))
+}
@@ -0,0 +1,49 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSideStringAndValid(t *testing.T) {
+	cases := []struct {
+		side  Side
+		str   string
+		valid bool
+	}{
+		{BookSideBid, "Bid", true},
+		{BookSideAsk, "Ask", true},
+		{Side(7), "Side(7)", false},
+	}
+	for _, tc := range cases {
+		if got := tc.side.String(); got != tc.str {
+			t.Errorf("Side(%d).String() = %q, want %q", tc.side, got, tc.str)
+		}
+		if got := tc.side.Valid(); got != tc.valid {
+			t.Errorf("Side(%d).Valid() = %v, want %v", tc.side, got, tc.valid)
+		}
+	}
+}
+
+func TestUnpackerRejectsIllegalSide(t *testing.T) {
+	book := OrderBookBody{TickerID: 1, FirstTick: 10, TickSize: 1, NumTicks: 2, Side: BookSideAsk}
+	data, err := PackOrderBook(MitchHeader{}, book, []uint32{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt the side byte to an out-of-range value.
+	data[HeaderSize+26] = 9
+
+	_, _, _, err = UnpackOrderBook(data)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Field != "OrderBookBody.Side" {
+		t.Errorf("UnpackOrderBook with side 9 = %v, want OrderBookBody.Side validation error", err)
+	}
+}
+
+func TestBookSideConstantsMatchWire(t *testing.T) {
+	// The typed constants keep the spec's 0=bids/1=asks encoding.
+	if byte(BookSideBid) != 0 || byte(BookSideAsk) != 1 {
+		t.Errorf("BookSideBid=%d BookSideAsk=%d, want 0 and 1", BookSideBid, BookSideAsk)
+	}
+}
@@ -0,0 +1,28 @@
+// Signed quantity convention for netting.
+//
+// Quantity is uint32 on the wire, so position reductions and short sizes
+// cannot be represented directly. The package convention is that sell-side
+// quantities are negative when signed: buys add to a position and sells
+// subtract, so summing signed quantities across a batch nets positions
+// unambiguously. The wire format is unchanged; sign is derived from the
+// side at the edge.
+
+package mitch
+
+// SignedQuantity returns the order's quantity signed by its side: positive
+// for buys, negative for sells.
+func SignedQuantity(body OrderBody) int64 {
+	if body.TypeAndSide&1 == SideSell {
+		return -int64(body.Quantity)
+	}
+	return int64(body.Quantity)
+}
+
+// SignedTradeQuantity returns the trade's quantity signed by its side
+// under the same convention: positive for buys, negative for sells.
+func SignedTradeQuantity(body TradeBody) int64 {
+	if body.Side == SideSell {
+		return -int64(body.Quantity)
+	}
+	return int64(body.Quantity)
+}
@@ -0,0 +1,38 @@
+package mitch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignedQuantity(t *testing.T) {
+	buy := OrderBody{Quantity: 100, TypeAndSide: OrderTypeLimit<<1 | SideBuy}
+	if got := SignedQuantity(buy); got != 100 {
+		t.Errorf("buy = %d, want 100", got)
+	}
+	sell := OrderBody{Quantity: 100, TypeAndSide: OrderTypeLimit<<1 | SideSell}
+	if got := SignedQuantity(sell); got != -100 {
+		t.Errorf("sell = %d, want -100", got)
+	}
+
+	// The full uint32 range must survive the sign conversion.
+	max := OrderBody{Quantity: math.MaxUint32, TypeAndSide: SideSell}
+	if got := SignedQuantity(max); got != -int64(math.MaxUint32) {
+		t.Errorf("max sell = %d, want %d", got, -int64(math.MaxUint32))
+	}
+}
+
+func TestSignedTradeQuantityNets(t *testing.T) {
+	trades := []TradeBody{
+		{Quantity: 300, Side: SideBuy},
+		{Quantity: 100, Side: SideSell},
+		{Quantity: 50, Side: SideSell},
+	}
+	var net int64
+	for _, trade := range trades {
+		net += SignedTradeQuantity(trade)
+	}
+	if net != 150 {
+		t.Errorf("net position = %d, want 150", net)
+	}
+}
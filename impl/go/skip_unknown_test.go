@@ -0,0 +1,53 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecoderSkipUnknown(t *testing.T) {
+	known, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A future message type 'z' with two standard 32-byte bodies.
+	unknown := make([]byte, HeaderSize+2*BodySizeFixed)
+	unknown[0] = 'z'
+	unknown[7] = 2
+
+	stream := append(append([]byte(nil), unknown...), known...)
+
+	// Default behavior: the unknown type aborts the stream.
+	strict := NewDecoder(bytes.NewReader(stream))
+	if _, _, err := strict.Decode(); !errors.Is(err, ErrUnknownMessageType) {
+		t.Fatalf("strict decode: got %v, want ErrUnknownMessageType", err)
+	}
+
+	// With SkipUnknown the decoder discards it and delivers the trade.
+	lenient := NewDecoder(bytes.NewReader(stream))
+	lenient.SkipUnknown(true)
+	header, bodies, err := lenient.Decode()
+	if err != nil {
+		t.Fatalf("lenient decode: %v", err)
+	}
+	if header.MessageType != MsgTypeTrade {
+		t.Errorf("type = %q, want trade", header.MessageType)
+	}
+	if got := bodies[0].(*TradeBody); got.TradeID != 7 {
+		t.Errorf("TradeID = %d, want 7", got.TradeID)
+	}
+}
+
+func TestDecoderSkipUnknownTruncated(t *testing.T) {
+	// Unknown message whose claimed bodies run past the end of the stream.
+	unknown := make([]byte, HeaderSize+BodySizeFixed/2)
+	unknown[0] = 'z'
+	unknown[7] = 1
+
+	decoder := NewDecoder(bytes.NewReader(unknown))
+	decoder.SkipUnknown(true)
+	if _, _, err := decoder.Decode(); err == nil {
+		t.Error("truncated unknown message must error")
+	}
+}
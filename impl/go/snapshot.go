@@ -0,0 +1,81 @@
+// Snapshot/incremental signaling for order book recovery.
+//
+// A client connecting mid-session needs a full snapshot before it can apply
+// incremental updates, but the wire format has no way to tell the two
+// apart. The first padding byte of the book header carries a snapshot flag:
+// it is zero on all previously-packed messages, so the zero value reads as
+// a plain incremental update and the flag is backwards compatible.
+
+package mitch
+
+// SnapshotFlag marks an order book message's role in snapshot recovery.
+// It is stored in Padding[0] of the book header.
+type SnapshotFlag uint8
+
+// Snapshot flag values. FlagIncremental is zero so unflagged messages are
+// incremental updates.
+const (
+	FlagIncremental   SnapshotFlag = 0 // normal incremental update
+	FlagSnapshotStart SnapshotFlag = 1 // first message of a snapshot
+	FlagSnapshotPart  SnapshotFlag = 2 // middle message of a snapshot
+	FlagSnapshotEnd   SnapshotFlag = 3 // last message of a snapshot
+)
+
+// String returns the flag name for logging.
+func (f SnapshotFlag) String() string {
+	switch f {
+	case FlagIncremental:
+		return "incremental"
+	case FlagSnapshotStart:
+		return "snapshot-start"
+	case FlagSnapshotPart:
+		return "snapshot-part"
+	case FlagSnapshotEnd:
+		return "snapshot-end"
+	}
+	return "unknown"
+}
+
+// SnapshotFlag returns the snapshot flag stored in the book header.
+func (b *OrderBookBody) SnapshotFlag() SnapshotFlag {
+	return SnapshotFlag(b.Padding[0])
+}
+
+// SetSnapshotFlag stores the snapshot flag in the book header's padding.
+func (b *OrderBookBody) SetSnapshotFlag(f SnapshotFlag) {
+	b.Padding[0] = byte(f)
+}
+
+// SnapshotTracker tells a consumer rebuilding state when the initial
+// snapshot is complete and incremental updates can be applied. Feed it
+// every received book in order via Observe.
+type SnapshotTracker struct {
+	inSnapshot bool
+	complete   bool
+}
+
+// Observe records one received book's flag and reports whether the book
+// should be applied now: snapshot messages always apply, incrementals only
+// once the snapshot has completed. A single-message snapshot may be flagged
+// FlagSnapshotEnd alone.
+func (t *SnapshotTracker) Observe(b *OrderBookBody) (apply bool) {
+	switch b.SnapshotFlag() {
+	case FlagSnapshotStart:
+		t.inSnapshot = true
+		t.complete = false
+		return true
+	case FlagSnapshotPart:
+		return true
+	case FlagSnapshotEnd:
+		t.inSnapshot = false
+		t.complete = true
+		return true
+	}
+	return t.complete
+}
+
+// Complete reports whether a full snapshot has been received, meaning
+// incremental updates are now safe to apply.
+func (t *SnapshotTracker) Complete() bool {
+	return t.complete
+}
@@ -0,0 +1,63 @@
+package mitch
+
+import "testing"
+
+func TestSnapshotFlagRoundTrip(t *testing.T) {
+	book := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 0.5, NumTicks: 2, Side: SideSell}
+	book.SetSnapshotFlag(FlagSnapshotStart)
+
+	message, err := PackOrderBook(MitchHeader{}, book, []uint32{10, 20})
+	if err != nil {
+		t.Fatalf("PackOrderBook: %v", err)
+	}
+	_, got, _, err := UnpackOrderBook(message)
+	if err != nil {
+		t.Fatalf("UnpackOrderBook: %v", err)
+	}
+	if got.SnapshotFlag() != FlagSnapshotStart {
+		t.Errorf("flag = %v, want %v", got.SnapshotFlag(), FlagSnapshotStart)
+	}
+	var zero OrderBookBody
+	if zero.SnapshotFlag() != FlagIncremental {
+		t.Errorf("zero book must read as incremental")
+	}
+}
+
+func TestSnapshotTracker(t *testing.T) {
+	flagged := func(f SnapshotFlag) *OrderBookBody {
+		b := &OrderBookBody{}
+		b.SetSnapshotFlag(f)
+		return b
+	}
+
+	var tracker SnapshotTracker
+	if tracker.Observe(flagged(FlagIncremental)) {
+		t.Error("incremental before snapshot must not apply")
+	}
+	if !tracker.Observe(flagged(FlagSnapshotStart)) ||
+		!tracker.Observe(flagged(FlagSnapshotPart)) ||
+		!tracker.Observe(flagged(FlagSnapshotEnd)) {
+		t.Error("snapshot messages must always apply")
+	}
+	if !tracker.Complete() {
+		t.Error("tracker should report the snapshot complete")
+	}
+	if !tracker.Observe(flagged(FlagIncremental)) {
+		t.Error("incremental after a completed snapshot must apply")
+	}
+}
+
+func TestSnapshotFlagString(t *testing.T) {
+	cases := map[SnapshotFlag]string{
+		FlagIncremental:   "incremental",
+		FlagSnapshotStart: "snapshot-start",
+		FlagSnapshotPart:  "snapshot-part",
+		FlagSnapshotEnd:   "snapshot-end",
+		SnapshotFlag(9):   "unknown",
+	}
+	for f, want := range cases {
+		if f.String() != want {
+			t.Errorf("%d.String() = %q, want %q", f, f.String(), want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Sparse volume encoding for wide, quiet order books.
+//
+// A 500-level book in a quiet market is ~2KB of mostly-zero volumes. The
+// sparse encoding stores only the non-zero levels as (uint16 index, uint32
+// volume) pairs: 6 bytes per populated level against 4 bytes per level
+// dense, so sparse is smaller whenever fewer than two thirds of the levels
+// are populated. The header's Padding[1] carries a flag so receivers can
+// tell the encodings apart, and Padding[2:4] carries the big-endian pair
+// count; NumTicks keeps the dense level count either way, letting the
+// unpacker reconstruct the full dense array.
+
+package mitch
+
+import (
+	"fmt"
+	"time"
+)
+
+// sparseVolumeFlag in Padding[1] marks a book whose volumes are encoded as
+// (index, volume) pairs.
+const sparseVolumeFlag = 1
+
+// sparsePairSize is the wire size of one (uint16 index, uint32 volume)
+// pair.
+const sparsePairSize = 6
+
+// IsSparse reports whether the book's volumes are sparse-encoded.
+func (b *OrderBookBody) IsSparse() bool {
+	return b.Padding[1] == sparseVolumeFlag
+}
+
+// sparsePairCount returns the number of encoded pairs of a sparse book.
+func (b *OrderBookBody) sparsePairCount() int {
+	return int(ByteOrder.Uint16(b.Padding[2:4]))
+}
+
+// PackOrderBookSparse serializes an order book message storing only the
+// non-zero volumes as (index, volume) pairs. len(volumes) must equal
+// book.NumTicks; indexes are level positions in the dense array. Use it
+// when fewer than two thirds of the levels are populated, otherwise
+// PackOrderBook is smaller.
+func PackOrderBookSparse(header MitchHeader, book OrderBookBody, volumes []uint32) ([]byte, error) {
+	if book.NumTicks == 0 {
+		return nil, fmt.Errorf("mitch: order book has zero ticks")
+	}
+	if len(volumes) != int(book.NumTicks) {
+		return nil, fmt.Errorf("mitch: volume count %d does not match NumTicks %d", len(volumes), book.NumTicks)
+	}
+
+	pairs := 0
+	for _, v := range volumes {
+		if v != 0 {
+			pairs++
+		}
+	}
+	book.Padding[1] = sparseVolumeFlag
+	ByteOrder.PutUint16(book.Padding[2:4], uint16(pairs))
+
+	header.MessageType = MsgTypeOrderBook
+	header.Count = 1
+	if ReadTimestamp48(header.Timestamp) == 0 {
+		header.Timestamp = WriteTimestamp48(nanosSinceMidnight(time.Now()))
+	}
+
+	data := make([]byte, HeaderSize+BodySizeFixed+pairs*sparsePairSize)
+	packHeaderInto(data, header)
+	packOrderBookBodyInto(data[HeaderSize:], book)
+	off := HeaderSize + BodySizeFixed
+	for i, v := range volumes {
+		if v == 0 {
+			continue
+		}
+		ByteOrder.PutUint16(data[off:off+2], uint16(i))
+		ByteOrder.PutUint32(data[off+2:off+6], v)
+		off += sparsePairSize
+	}
+	return data, nil
+}
+
+// UnpackOrderBookSparse deserializes a sparse order book message and
+// reconstructs the full dense volume array of NumTicks entries. The
+// returned book has its sparse flag cleared so it round-trips through the
+// dense packer.
+func UnpackOrderBookSparse(data []byte) (*MitchHeader, *OrderBookBody, []uint32, error) {
+	if len(data) < HeaderSize+BodySizeFixed {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: order book message is %d bytes (need at least %d)", ErrShortBuffer, len(data), HeaderSize+BodySizeFixed)
+	}
+
+	header := unpackHeader(data)
+	if header.MessageType != MsgTypeOrderBook {
+		return nil, nil, nil, fmt.Errorf("mitch: message type %q is not an order book", header.MessageType)
+	}
+	book := unpackOrderBookBody(data[HeaderSize:])
+	if !book.IsSparse() {
+		return nil, nil, nil, fmt.Errorf("mitch: order book is not sparse-encoded; use UnpackOrderBook")
+	}
+
+	if err := book.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkBookLevels(book.NumTicks); err != nil {
+		return nil, nil, nil, err
+	}
+	pairs := book.sparsePairCount()
+	expected := HeaderSize + BodySizeFixed + pairs*sparsePairSize
+	if len(data) < expected {
+		return nil, nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d pairs, got %d", ErrShortBuffer, expected, pairs, len(data))
+	}
+
+	volumes := make([]uint32, book.NumTicks)
+	off := HeaderSize + BodySizeFixed
+	for i := 0; i < pairs; i++ {
+		index := int(ByteOrder.Uint16(data[off : off+2]))
+		if index >= int(book.NumTicks) {
+			return nil, nil, nil, fmt.Errorf("mitch: sparse pair %d index %d out of range (book has %d ticks)", i, index, book.NumTicks)
+		}
+		volumes[index] = ByteOrder.Uint32(data[off+2 : off+6])
+		off += sparsePairSize
+	}
+
+	book.Padding[1] = 0
+	book.Padding[2], book.Padding[3] = 0, 0
+	return header, book, volumes, nil
+}
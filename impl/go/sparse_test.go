@@ -0,0 +1,90 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSparseOrderBookRoundTrip(t *testing.T) {
+	book := OrderBookBody{TickerID: 7, FirstTick: 100, TickSize: 0.5, NumTicks: 500, Side: SideSell}
+	volumes := make([]uint32, 500)
+	volumes[0] = 10
+	volumes[123] = 20
+	volumes[499] = 30
+
+	message, err := PackOrderBookSparse(MitchHeader{}, book, volumes)
+	if err != nil {
+		t.Fatalf("PackOrderBookSparse: %v", err)
+	}
+	dense, err := PackOrderBook(MitchHeader{}, book, volumes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(message) >= len(dense) {
+		t.Errorf("sparse is %d bytes, dense %d; sparse should win at 3/500 populated", len(message), len(dense))
+	}
+
+	_, gotBook, gotVolumes, err := UnpackOrderBookSparse(message)
+	if err != nil {
+		t.Fatalf("UnpackOrderBookSparse: %v", err)
+	}
+	if gotBook.IsSparse() {
+		t.Error("unpacked book should have the sparse flag cleared")
+	}
+	if *gotBook != book {
+		t.Errorf("book = %+v, want %+v", *gotBook, book)
+	}
+	if len(gotVolumes) != 500 {
+		t.Fatalf("got %d volumes, want 500", len(gotVolumes))
+	}
+	for i, v := range gotVolumes {
+		want := volumes[i]
+		if v != want {
+			t.Errorf("volume %d = %d, want %d", i, v, want)
+		}
+	}
+}
+
+func TestSparseAllZero(t *testing.T) {
+	book := OrderBookBody{TickerID: 1, FirstTick: 10, TickSize: 1, NumTicks: 8, Side: SideBuy}
+	message, err := PackOrderBookSparse(MitchHeader{}, book, make([]uint32, 8))
+	if err != nil {
+		t.Fatalf("PackOrderBookSparse: %v", err)
+	}
+	if len(message) != HeaderSize+BodySizeFixed {
+		t.Errorf("all-zero book packed to %d bytes, want %d", len(message), HeaderSize+BodySizeFixed)
+	}
+	_, _, volumes, err := UnpackOrderBookSparse(message)
+	if err != nil {
+		t.Fatalf("UnpackOrderBookSparse: %v", err)
+	}
+	for i, v := range volumes {
+		if v != 0 {
+			t.Errorf("volume %d = %d, want 0", i, v)
+		}
+	}
+}
+
+func TestSparseErrors(t *testing.T) {
+	book := OrderBookBody{TickerID: 1, FirstTick: 10, TickSize: 1, NumTicks: 4, Side: SideBuy}
+	if _, err := PackOrderBookSparse(MitchHeader{}, book, []uint32{1, 2}); err == nil {
+		t.Error("volume count mismatch must error")
+	}
+
+	// A dense message must be refused by the sparse unpacker.
+	dense, err := PackOrderBook(MitchHeader{}, book, []uint32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := UnpackOrderBookSparse(dense); err == nil {
+		t.Error("dense message must be rejected by UnpackOrderBookSparse")
+	}
+
+	sparse, err := PackOrderBookSparse(MitchHeader{}, book, []uint32{1, 0, 0, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := UnpackOrderBookSparse(sparse[:len(sparse)-3]); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("truncated sparse message: got %v, want ErrShortBuffer", err)
+	}
+}
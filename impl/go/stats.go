@@ -0,0 +1,69 @@
+// Per-message-type processing counters.
+//
+// Monitoring wants to know how many messages and bodies of each type a
+// consumer has processed. Counters are updated atomically so a metrics
+// goroutine can snapshot them while the decode loop runs.
+
+package mitch
+
+import "sync/atomic"
+
+// Counts holds the totals for one message type.
+type Counts struct {
+	Messages uint64
+	Bodies   uint64
+}
+
+// statsSlots maps the known type bytes onto fixed atomic counter slots so
+// incrementing stays lock-free.
+var statsSlots = map[MessageType]int{
+	MsgTypeTrade:        0,
+	MsgTypeOrder:        1,
+	MsgTypeTicker:       2,
+	MsgTypeOrderBook:    3,
+	MsgTypeHeartbeat:    4,
+	MsgTypeMixed:        5,
+	MsgTypeBookChecksum: 6,
+}
+
+const numStatsSlots = 7
+
+// Stats counts processed messages and bodies per message type. The zero
+// value is ready to use and all methods are safe for concurrent use.
+type Stats struct {
+	messages [numStatsSlots]atomic.Uint64
+	bodies   [numStatsSlots]atomic.Uint64
+}
+
+// Record counts one message of the given type carrying bodyCount bodies.
+// Unknown types are ignored.
+func (s *Stats) Record(messageType MessageType, bodyCount int) {
+	slot, ok := statsSlots[messageType]
+	if !ok {
+		return
+	}
+	s.messages[slot].Add(1)
+	s.bodies[slot].Add(uint64(bodyCount))
+}
+
+// Snapshot returns the current totals keyed by message type, omitting
+// types that have not been seen.
+func (s *Stats) Snapshot() map[MessageType]Counts {
+	snapshot := make(map[MessageType]Counts)
+	for messageType, slot := range statsSlots {
+		messages := s.messages[slot].Load()
+		bodies := s.bodies[slot].Load()
+		if messages != 0 || bodies != 0 {
+			snapshot[messageType] = Counts{Messages: messages, Bodies: bodies}
+		}
+	}
+	return snapshot
+}
+
+// Reset zeroes all counters for windowed reporting.
+func (s *Stats) Reset() {
+	for i := 0; i < numStatsSlots; i++ {
+		s.messages[i].Store(0)
+		s.bodies[i].Store(0)
+	}
+}
@@ -0,0 +1,68 @@
+package mitch
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestStatsWithDecoder(t *testing.T) {
+	var stream bytes.Buffer
+	trades, _ := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1}, TradeBody{TradeID: 2})
+	ticker, _ := PackMessage(MsgTypeTicker, TickerBody{TickerID: 3})
+	stream.Write(trades)
+	stream.Write(ticker)
+	stream.Write(trades)
+
+	var stats Stats
+	decoder := NewDecoder(&stream)
+	decoder.CollectStats(&stats)
+	for i := 0; i < 3; i++ {
+		if _, _, err := decoder.Decode(); err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+	}
+
+	snapshot := stats.Snapshot()
+	if got := snapshot[MsgTypeTrade]; got != (Counts{Messages: 2, Bodies: 4}) {
+		t.Errorf("trade counts = %+v, want {2 4}", got)
+	}
+	if got := snapshot[MsgTypeTicker]; got != (Counts{Messages: 1, Bodies: 1}) {
+		t.Errorf("ticker counts = %+v, want {1 1}", got)
+	}
+	if _, ok := snapshot[MsgTypeOrder]; ok {
+		t.Error("unseen types should be omitted from the snapshot")
+	}
+
+	stats.Reset()
+	if len(stats.Snapshot()) != 0 {
+		t.Error("Reset should zero all counters")
+	}
+}
+
+func TestStatsConcurrent(t *testing.T) {
+	var stats Stats
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				stats.Record(MsgTypeTrade, 2)
+				stats.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := stats.Snapshot()[MsgTypeTrade]; got != (Counts{Messages: 4000, Bodies: 8000}) {
+		t.Errorf("counts = %+v, want {4000 8000}", got)
+	}
+}
+
+func TestStatsIgnoresUnknownTypes(t *testing.T) {
+	var stats Stats
+	stats.Record('z', 1)
+	if len(stats.Snapshot()) != 0 {
+		t.Error("unknown types must not be counted")
+	}
+}
@@ -0,0 +1,38 @@
+// Human-readable body dumps for debugging.
+//
+// The struct default %+v rendering leaves TypeAndSide and Expiry as raw
+// numbers; these Stringers decode them so a log line reads
+// "LIMIT BUY 100 @ 1.2345" instead of "TypeAndSide:3". They are debug
+// helpers, not wire or JSON formats, and are safe on zero values.
+
+package mitch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String renders the trade in a compact human-readable form.
+func (t *TradeBody) String() string {
+	return fmt.Sprintf("trade{ticker=%d id=%d %s %d @ %g}",
+		t.TickerID, t.TradeID, strings.ToUpper(sideString(t.Side)), t.Quantity, t.Price)
+}
+
+// String renders the order with its type, side and expiry decoded.
+func (o *OrderBody) String() string {
+	expiry := "GTC"
+	if o.HasExpiry() {
+		expiry = fmt.Sprintf("expires %dns", ReadTimestamp48(o.Expiry))
+	}
+	return fmt.Sprintf("order{ticker=%d id=%d %s %s %d @ %g %s}",
+		o.TickerID, o.OrderID,
+		strings.ToUpper(orderTypeString(o.TypeAndSide>>1)),
+		strings.ToUpper(sideString(o.TypeAndSide&1)),
+		o.Quantity, o.Price, expiry)
+}
+
+// String renders the quote as bid/ask with volumes.
+func (t *TickerBody) String() string {
+	return fmt.Sprintf("ticker{ticker=%d bid %d @ %g / ask %d @ %g}",
+		t.TickerID, t.BidVolume, t.BidPrice, t.AskVolume, t.AskPrice)
+}
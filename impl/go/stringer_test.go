@@ -0,0 +1,58 @@
+package mitch
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrderBodyString(t *testing.T) {
+	order := OrderBody{
+		TickerID:    7,
+		OrderID:     42,
+		Price:       1.2345,
+		Quantity:    100,
+		TypeAndSide: OrderTypeLimit<<1 | SideBuy,
+	}
+	got := order.String()
+	for _, want := range []string{"LIMIT", "BUY", "1.2345", "100", "GTC"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, missing %q", got, want)
+		}
+	}
+
+	order.SetExpiry(time.Date(2025, 1, 2, 15, 0, 0, 0, time.UTC))
+	if got := order.String(); strings.Contains(got, "GTC") {
+		t.Errorf("String() = %q still says GTC after SetExpiry", got)
+	}
+}
+
+func TestTradeAndTickerString(t *testing.T) {
+	trade := TradeBody{TickerID: 1, TradeID: 9, Price: 10.5, Quantity: 3, Side: SideSell}
+	if got := trade.String(); !strings.Contains(got, "SELL") || !strings.Contains(got, "10.5") {
+		t.Errorf("trade String() = %q", got)
+	}
+
+	ticker := TickerBody{TickerID: 2, BidPrice: 99, AskPrice: 101, BidVolume: 5, AskVolume: 6}
+	got := ticker.String()
+	if !strings.Contains(got, "99") || !strings.Contains(got, "101") {
+		t.Errorf("ticker String() = %q", got)
+	}
+}
+
+func TestStringZeroValues(t *testing.T) {
+	// Zero values must render without panicking or mislabeling.
+	var (
+		trade  TradeBody
+		order  OrderBody
+		ticker TickerBody
+	)
+	for _, s := range []string{trade.String(), order.String(), ticker.String()} {
+		if s == "" {
+			t.Error("zero value rendered empty")
+		}
+	}
+	if !strings.Contains(order.String(), "GTC") {
+		t.Errorf("zero order = %q, want GTC", order.String())
+	}
+}
@@ -0,0 +1,42 @@
+// Synthetic trades for gap filling from the ticker feed.
+//
+// When the trade feed drops but tickers keep flowing, continuity-sensitive
+// consumers (charting, bars, last-price caches) can synthesize a
+// pseudo-trade at the mid-price rather than show a dead instrument. These
+// are NOT real executions: they carry no true quantity or aggressor side,
+// and any analytics measuring actual market activity — volume profiles,
+// trade counts, VWAP — must exclude them. The synthetic marker lives in a
+// reserved padding bit so real trades are unaffected and the wire layout
+// is unchanged.
+
+package mitch
+
+// synthTradeFlag in TradeBody.Padding[0] marks a trade synthesized from
+// ticker data rather than reported by the venue.
+const synthTradeFlag = 1
+
+// IsSynthetic reports whether the trade was synthesized from ticker data
+// rather than executed at the venue.
+func (t *TradeBody) IsSynthetic() bool {
+	return t.Padding[0]&synthTradeFlag != 0
+}
+
+// SynthTrade builds a synthetic trade at the ticker's mid-price, flagged
+// so downstream analytics can exclude it. Quantity is zero — no volume
+// actually traded — and Side is SideBuy purely as a placeholder. With an
+// empty quote (both prices zero) the mid is whichever side is populated,
+// or zero when neither is.
+func SynthTrade(t *TickerBody, tradeID uint32) TradeBody {
+	price := (t.BidPrice + t.AskPrice) / 2
+	if t.BidPrice == 0 || t.AskPrice == 0 {
+		price = t.BidPrice + t.AskPrice
+	}
+	trade := TradeBody{
+		TickerID: t.TickerID,
+		Price:    price,
+		TradeID:  tradeID,
+		Side:     SideBuy,
+	}
+	trade.Padding[0] |= synthTradeFlag
+	return trade
+}
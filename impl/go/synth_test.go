@@ -0,0 +1,59 @@
+package mitch
+
+import "testing"
+
+func TestSynthTrade(t *testing.T) {
+	ticker := TickerBody{
+		TickerID:  77,
+		BidPrice:  99.0,
+		AskPrice:  101.0,
+		BidVolume: 10,
+		AskVolume: 20,
+	}
+	trade := SynthTrade(&ticker, 42)
+	if trade.TickerID != 77 || trade.TradeID != 42 {
+		t.Errorf("trade = %+v", trade)
+	}
+	if trade.Price != 100.0 {
+		t.Errorf("Price = %v, want mid 100.0", trade.Price)
+	}
+	if trade.Quantity != 0 {
+		t.Errorf("Quantity = %d, want 0 for a synthetic trade", trade.Quantity)
+	}
+	if !trade.IsSynthetic() {
+		t.Error("synthesized trade not flagged synthetic")
+	}
+}
+
+func TestSynthTradeOneSidedQuote(t *testing.T) {
+	bidOnly := TickerBody{TickerID: 1, BidPrice: 50.0}
+	if got := SynthTrade(&bidOnly, 1).Price; got != 50.0 {
+		t.Errorf("bid-only mid = %v, want 50.0", got)
+	}
+	empty := TickerBody{TickerID: 1}
+	if got := SynthTrade(&empty, 2).Price; got != 0 {
+		t.Errorf("empty-quote mid = %v, want 0", got)
+	}
+}
+
+func TestSynthTradeFlagSurvivesRoundTrip(t *testing.T) {
+	ticker := TickerBody{TickerID: 5, BidPrice: 10, AskPrice: 12}
+	trade := SynthTrade(&ticker, 9)
+
+	data, err := trade.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TradeBody
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsSynthetic() {
+		t.Error("synthetic flag lost across marshal/unmarshal")
+	}
+
+	real := TradeBody{TickerID: 5, Price: 11, Quantity: 3, TradeID: 10, Side: SideSell}
+	if real.IsSynthetic() {
+		t.Error("real trade flagged synthetic")
+	}
+}
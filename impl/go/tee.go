@@ -0,0 +1,40 @@
+// Feed recording alongside live consumption.
+//
+// TeeDecoder records a production feed to disk for later FileReader replay
+// without perturbing the consumer: every byte the decoder reads off the
+// wire is first copied to the capture writer, so the recording is exactly
+// the raw stream, order-book volume arrays included.
+
+package mitch
+
+import "io"
+
+// TeeDecoder decodes a stream like Decoder while writing the raw bytes of
+// every message it reads to a capture writer. A write error on the capture
+// side surfaces from Decode, since a silently truncated recording is worse
+// than a stopped consumer.
+type TeeDecoder struct {
+	inner *Decoder
+}
+
+// NewTeeDecoder returns a decoder reading from r that copies all consumed
+// bytes to w.
+func NewTeeDecoder(r io.Reader, w io.Writer) *TeeDecoder {
+	return &TeeDecoder{inner: NewDecoder(io.TeeReader(r, w))}
+}
+
+// Validate controls body validation, as on Decoder.
+func (t *TeeDecoder) Validate(enable bool) {
+	t.inner.Validate(enable)
+}
+
+// CollectStats wires a Stats collector, as on Decoder.
+func (t *TeeDecoder) CollectStats(stats *Stats) {
+	t.inner.CollectStats(stats)
+}
+
+// Decode reads and parses the next message; the raw bytes have been
+// written to the capture writer by the time it returns.
+func (t *TeeDecoder) Decode() (*MitchHeader, []interface{}, error) {
+	return t.inner.Decode()
+}
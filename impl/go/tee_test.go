@@ -0,0 +1,63 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestTeeDecoderRecordsRawBytes(t *testing.T) {
+	trades, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 2, FirstTick: 10, TickSize: 1, NumTicks: 3, Side: SideBuy},
+		[]uint32{7, 8, 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := append(append([]byte(nil), trades...), book...)
+
+	var capture bytes.Buffer
+	decoder := NewTeeDecoder(bytes.NewReader(stream), &capture)
+	for i := 0; i < 2; i++ {
+		if _, _, err := decoder.Decode(); err != nil {
+			t.Fatalf("Decode %d: %v", i, err)
+		}
+	}
+	if _, _, err := decoder.Decode(); !errors.Is(err, io.EOF) {
+		t.Fatalf("end of stream: got %v, want io.EOF", err)
+	}
+
+	if !bytes.Equal(capture.Bytes(), stream) {
+		t.Errorf("capture diverges from the wire bytes:\ngot  % X\nwant % X", capture.Bytes(), stream)
+	}
+
+	// The capture must replay through a fresh decoder.
+	replay := NewDecoder(&capture)
+	if _, _, err := replay.Decode(); err != nil {
+		t.Errorf("replaying the capture: %v", err)
+	}
+	_, bodies, err := replay.Decode()
+	if err != nil {
+		t.Fatalf("replaying the book: %v", err)
+	}
+	entry := bodies[0].(*OrderBookEntry)
+	if len(entry.Volumes) != 3 || entry.Volumes[2] != 9 {
+		t.Errorf("replayed volumes = %v, want [7 8 9]", entry.Volumes)
+	}
+}
+
+func TestTeeDecoderSurfacesCaptureErrors(t *testing.T) {
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantErr := errors.New("disk full")
+	decoder := NewTeeDecoder(bytes.NewReader(message), &failWriter{n: 0, err: wantErr})
+	if _, _, err := decoder.Decode(); !errors.Is(err, wantErr) {
+		t.Errorf("Decode err = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,43 @@
+// TickerBody quote arithmetic.
+//
+// Consumers of ticker feeds keep re-deriving the same quantities from the
+// bid/ask pair; these helpers centralize them.
+
+package mitch
+
+// Spread returns the quoted spread, AskPrice - BidPrice.
+func (t *TickerBody) Spread() float64 {
+	return t.AskPrice - t.BidPrice
+}
+
+// Mid returns the midpoint between bid and ask.
+func (t *TickerBody) Mid() float64 {
+	return (t.BidPrice + t.AskPrice) / 2
+}
+
+// IsCrossed reports whether the market is crossed or locked (bid >= ask),
+// which usually signals bad data worth flagging before it propagates.
+func (t *TickerBody) IsCrossed() bool {
+	return t.BidPrice >= t.AskPrice
+}
+
+// Imbalance returns the order-flow imbalance (BidVolume - AskVolume) /
+// (BidVolume + AskVolume), ranging from 1 (all bid) to -1 (all ask). An
+// empty quote with both volumes zero returns 0 rather than NaN.
+func (t *TickerBody) Imbalance() float64 {
+	total := uint64(t.BidVolume) + uint64(t.AskVolume)
+	if total == 0 {
+		return 0
+	}
+	return (float64(t.BidVolume) - float64(t.AskVolume)) / float64(total)
+}
+
+// DecodeTicker decodes one 32-byte ticker body with direct big-endian
+// reads and no reflection. Tickers are the most frequent message on most
+// feeds, so this is the hot decode path; it returns by value to keep the
+// body on the caller's stack.
+func DecodeTicker(data []byte) (TickerBody, error) {
+	var t TickerBody
+	err := t.UnmarshalBinary(data)
+	return t, err
+}
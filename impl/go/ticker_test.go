@@ -0,0 +1,90 @@
+package mitch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestTickerSpreadMid(t *testing.T) {
+	ticker := TickerBody{BidPrice: 100.0, AskPrice: 100.5}
+	if got := ticker.Spread(); got != 0.5 {
+		t.Errorf("Spread() = %g, want 0.5", got)
+	}
+	if got := ticker.Mid(); got != 100.25 {
+		t.Errorf("Mid() = %g, want 100.25", got)
+	}
+	if ticker.IsCrossed() {
+		t.Error("normal market reported as crossed")
+	}
+}
+
+func TestTickerIsCrossed(t *testing.T) {
+	crossed := TickerBody{BidPrice: 101.0, AskPrice: 100.0}
+	if !crossed.IsCrossed() {
+		t.Error("bid above ask must report crossed")
+	}
+	locked := TickerBody{BidPrice: 100.0, AskPrice: 100.0}
+	if !locked.IsCrossed() {
+		t.Error("locked market (bid == ask) must report crossed")
+	}
+}
+
+func TestDecodeTicker(t *testing.T) {
+	want := TickerBody{TickerID: 7, BidPrice: 99.5, AskPrice: 100.5, BidVolume: 11, AskVolume: 22}
+	data, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeTicker(data)
+	if err != nil {
+		t.Fatalf("DecodeTicker: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if _, err := DecodeTicker(data[:10]); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("short input err = %v, want ErrShortBuffer", err)
+	}
+}
+
+func BenchmarkDecodeTicker(b *testing.B) {
+	data, _ := TickerBody{TickerID: 7, BidPrice: 99.5, AskPrice: 100.5}.MarshalBinary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeTicker(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeTickerBinaryRead(b *testing.B) {
+	data, _ := TickerBody{TickerID: 7, BidPrice: 99.5, AskPrice: 100.5}.MarshalBinary()
+	b.ReportAllocs()
+	var body TickerBody
+	for i := 0; i < b.N; i++ {
+		if err := binary.Read(bytes.NewReader(data), ByteOrder, &body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestImbalance(t *testing.T) {
+	tests := []struct {
+		bid, ask uint32
+		want     float64
+	}{
+		{100, 100, 0},
+		{100, 0, 1},
+		{0, 100, -1},
+		{300, 100, 0.5},
+		{0, 0, 0}, // empty quote must not divide by zero
+	}
+	for _, tt := range tests {
+		ticker := TickerBody{BidVolume: tt.bid, AskVolume: tt.ask}
+		if got := ticker.Imbalance(); got != tt.want {
+			t.Errorf("Imbalance(%d, %d) = %v, want %v", tt.bid, tt.ask, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Conversions between 48-bit MITCH timestamps and time.Time.
+//
+// The wire timestamp is nanoseconds since midnight UTC, so it carries no
+// date: callers supply the trading day to recover an absolute time.
+
+package mitch
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxTimestamp48 is the largest value representable in 48 bits.
+const maxTimestamp48 = 1<<48 - 1
+
+// nanosPerDay is one full day in nanoseconds (~8.64e13, well within 48 bits).
+const nanosPerDay = 24 * 60 * 60 * 1e9
+
+// WriteTimestamp48Checked packs a nanoseconds-since-midnight timestamp into
+// 6 big-endian bytes, erroring if the value exceeds 48 bits instead of
+// silently masking the upper bits like WriteTimestamp48. Passing a full
+// 64-bit epoch value by mistake is caught here rather than producing
+// garbage bytes.
+func WriteTimestamp48Checked(timestamp uint64) ([6]byte, error) {
+	if timestamp > maxTimestamp48 {
+		return [6]byte{}, fmt.Errorf("mitch: timestamp %d exceeds 48 bits (max %d)", timestamp, uint64(maxTimestamp48))
+	}
+	return WriteTimestamp48(timestamp), nil
+}
+
+// TimestampToTime combines a nanoseconds-since-midnight timestamp with the
+// given day's UTC date into an absolute time. Exactly midnight decodes to
+// the day's midnight; 23:59:59.999999999 decodes to one nanosecond before
+// the next midnight.
+func TimestampToTime(ts [6]byte, day time.Time) time.Time {
+	day = day.UTC()
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return midnight.Add(time.Duration(ReadTimestamp48(ts)))
+}
+
+// MillisSinceMidnight reads the timestamp at millisecond precision,
+// truncating the sub-millisecond remainder. A day is under 86.4 million
+// milliseconds, so the value always fits a uint32.
+func MillisSinceMidnight(ts [6]byte) uint32 {
+	return uint32(ReadTimestamp48(ts) / uint64(time.Millisecond))
+}
+
+// MicrosSinceMidnight reads the timestamp at microsecond precision,
+// truncating the sub-microsecond remainder.
+func MicrosSinceMidnight(ts [6]byte) uint64 {
+	return ReadTimestamp48(ts) / uint64(time.Microsecond)
+}
+
+// WriteTimestamp48Millis packs a milliseconds-since-midnight value,
+// scaling to the wire's nanosecond unit. Any in-day value fits 48 bits.
+func WriteTimestamp48Millis(millis uint32) [6]byte {
+	return WriteTimestamp48(uint64(millis) * uint64(time.Millisecond))
+}
+
+// WriteTimestamp48Micros packs a microseconds-since-midnight value,
+// scaling to the wire's nanosecond unit.
+func WriteTimestamp48Micros(micros uint64) [6]byte {
+	return WriteTimestamp48(micros * uint64(time.Microsecond))
+}
+
+// TimeToTimestamp48 extracts the nanoseconds elapsed since t's UTC midnight
+// as a 48-bit timestamp. It errors if the value would exceed 48 bits, which
+// cannot happen for a real wall-clock time (a full day is ~8.64e13 ns) but
+// guards against nonsensical inputs.
+func TimeToTimestamp48(t time.Time) ([6]byte, error) {
+	nanos := nanosSinceMidnight(t)
+	if nanos > maxTimestamp48 {
+		return [6]byte{}, fmt.Errorf("mitch: timestamp %d exceeds 48 bits", nanos)
+	}
+	return WriteTimestamp48(nanos), nil
+}
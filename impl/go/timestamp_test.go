@@ -0,0 +1,105 @@
+package mitch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampRoundTrip(t *testing.T) {
+	day := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		when time.Time
+	}{
+		{"midnight", day},
+		{"morning", day.Add(9*time.Hour + 30*time.Minute)},
+		{"last nanosecond", day.Add(24*time.Hour - time.Nanosecond)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, err := TimeToTimestamp48(tc.when)
+			if err != nil {
+				t.Fatalf("TimeToTimestamp48 failed: %v", err)
+			}
+			got := TimestampToTime(ts, day)
+			if !got.Equal(tc.when) {
+				t.Errorf("round trip = %v, want %v", got, tc.when)
+			}
+		})
+	}
+}
+
+func TestWriteTimestamp48Checked(t *testing.T) {
+	ts, err := WriteTimestamp48Checked(maxTimestamp48)
+	if err != nil {
+		t.Fatalf("WriteTimestamp48Checked(max) failed: %v", err)
+	}
+	if got := ReadTimestamp48(ts); got != maxTimestamp48 {
+		t.Errorf("round trip = %d, want %d", got, uint64(maxTimestamp48))
+	}
+
+	// A full 64-bit epoch value must be rejected, not masked.
+	if _, err := WriteTimestamp48Checked(uint64(time.Now().UnixNano())); err == nil {
+		t.Error("expected error for epoch nanos exceeding 48 bits")
+	}
+	if _, err := WriteTimestamp48Checked(maxTimestamp48 + 1); err == nil {
+		t.Error("expected error for 2^48")
+	}
+}
+
+func TestTimestampAtMidnightIsZero(t *testing.T) {
+	ts, err := TimeToTimestamp48(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("TimeToTimestamp48 failed: %v", err)
+	}
+	if ReadTimestamp48(ts) != 0 {
+		t.Errorf("midnight timestamp = %d, want 0", ReadTimestamp48(ts))
+	}
+}
+
+func TestTimestampEndOfDay(t *testing.T) {
+	when := time.Date(2024, 3, 15, 23, 59, 59, 999999999, time.UTC)
+	ts, err := TimeToTimestamp48(when)
+	if err != nil {
+		t.Fatalf("TimeToTimestamp48 failed: %v", err)
+	}
+	want := uint64(nanosPerDay - 1)
+	if got := ReadTimestamp48(ts); got != want {
+		t.Errorf("end-of-day timestamp = %d, want %d", got, want)
+	}
+}
+
+func TestCoarsePrecisionAccessors(t *testing.T) {
+	// 10:30:00.123456789 after midnight.
+	nanos := uint64(10*3600+30*60)*1e9 + 123456789
+	ts := WriteTimestamp48(nanos)
+
+	if got, want := MillisSinceMidnight(ts), uint32(nanos/1e6); got != want {
+		t.Errorf("MillisSinceMidnight = %d, want %d", got, want)
+	}
+	if got, want := MicrosSinceMidnight(ts), nanos/1e3; got != want {
+		t.Errorf("MicrosSinceMidnight = %d, want %d", got, want)
+	}
+	// Sub-unit remainders truncate rather than round.
+	if got := MillisSinceMidnight(WriteTimestamp48(1999999)); got != 1 {
+		t.Errorf("1999999ns = %dms, want 1", got)
+	}
+}
+
+func TestCoarsePrecisionPacking(t *testing.T) {
+	const millis = uint32(37_800_123) // 10:30:00.123
+	ts := WriteTimestamp48Millis(millis)
+	if got := ReadTimestamp48(ts); got != uint64(millis)*1e6 {
+		t.Errorf("millis pack = %dns, want %d", got, uint64(millis)*1e6)
+	}
+	if got := MillisSinceMidnight(ts); got != millis {
+		t.Errorf("millis round trip = %d, want %d", got, millis)
+	}
+
+	const micros = uint64(37_800_123_456)
+	ts = WriteTimestamp48Micros(micros)
+	if got := MicrosSinceMidnight(ts); got != micros {
+		t.Errorf("micros round trip = %d, want %d", got, micros)
+	}
+}
@@ -0,0 +1,30 @@
+// Depth truncation for bandwidth-constrained forwarding.
+//
+// A client on a thin pipe rarely needs a 500-level grid; the top of book
+// is where the information is. TruncateBook cuts a book to its best N
+// levels. Levels are stored best-first on both sides (see LevelPrice), so
+// the cut is a prefix and FirstTick stays anchored at the best level —
+// unlike price-ascending layouts, truncating bids here does not move the
+// starting tick.
+
+package mitch
+
+// TruncateBook returns the book capped to its best n levels, with
+// NumTicks adjusted to match. The returned volume slice shares the
+// input's backing array. n of zero returns an empty book; n covering all
+// levels returns the inputs unchanged.
+func TruncateBook(b OrderBookBody, volumes []uint32, n int) (OrderBookBody, []uint32) {
+	if n <= 0 {
+		b.NumTicks = 0
+		return b, volumes[:0]
+	}
+	levels := int(b.NumTicks)
+	if len(volumes) < levels {
+		levels = len(volumes)
+	}
+	if n >= levels {
+		return b, volumes
+	}
+	b.NumTicks = uint16(n)
+	return b, volumes[:n]
+}
@@ -0,0 +1,58 @@
+package mitch
+
+import "testing"
+
+func TestTruncateBook(t *testing.T) {
+	bids := OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 0.5, NumTicks: 5, Side: BookSideBid}
+	volumes := []uint32{10, 20, 30, 40, 50}
+
+	top, topVolumes := TruncateBook(bids, volumes, 2)
+	if top.NumTicks != 2 || len(topVolumes) != 2 {
+		t.Fatalf("truncated to NumTicks %d / %d volumes, want 2", top.NumTicks, len(topVolumes))
+	}
+	// Best-first storage keeps FirstTick anchored at the best bid.
+	if top.FirstTick != 100 {
+		t.Errorf("FirstTick = %v, want 100", top.FirstTick)
+	}
+	if topVolumes[0] != 10 || topVolumes[1] != 20 {
+		t.Errorf("volumes = %v, want the best two", topVolumes)
+	}
+	// The second-best bid still prices below the best.
+	if got := top.LevelPrice(1); got != 99.5 {
+		t.Errorf("LevelPrice(1) = %v, want 99.5", got)
+	}
+}
+
+func TestTruncateBookEdgeCases(t *testing.T) {
+	asks := OrderBookBody{TickerID: 1, FirstTick: 101, TickSize: 0.5, NumTicks: 3, Side: BookSideAsk}
+	volumes := []uint32{1, 2, 3}
+
+	// n covering every level leaves the book unchanged.
+	same, sameVolumes := TruncateBook(asks, volumes, 10)
+	if same.NumTicks != 3 || len(sameVolumes) != 3 {
+		t.Errorf("n beyond depth changed the book: %+v, %v", same, sameVolumes)
+	}
+
+	// n of zero empties the book.
+	empty, emptyVolumes := TruncateBook(asks, volumes, 0)
+	if empty.NumTicks != 0 || len(emptyVolumes) != 0 {
+		t.Errorf("n=0 = NumTicks %d, %d volumes; want an empty book", empty.NumTicks, len(emptyVolumes))
+	}
+}
+
+func TestTruncateBookRoundTrip(t *testing.T) {
+	book := OrderBookBody{TickerID: 9, FirstTick: 50, TickSize: 1, NumTicks: 4, Side: BookSideAsk}
+	top, topVolumes := TruncateBook(book, []uint32{5, 6, 7, 8}, 3)
+
+	data, err := PackOrderBook(MitchHeader{}, top, topVolumes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, decoded, decodedVolumes, err := UnpackOrderBook(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.NumTicks != 3 || decodedVolumes[2] != 7 {
+		t.Errorf("round trip = %+v, %v", decoded, decodedVolumes)
+	}
+}
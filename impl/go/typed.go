@@ -0,0 +1,75 @@
+// Generic, compile-time-homogeneous packing.
+//
+// PackMessage takes ...interface{} so nothing stops a caller from mixing a
+// TradeBody and an OrderBody in one batch until runtime. PackTyped
+// constrains the whole batch to a single body type at compile time and
+// delegates to the same packing path.
+
+package mitch
+
+import "fmt"
+
+// Body constrains a generic batch to exactly one of the four wire body
+// types. The embedded method keeps the constraint aligned with what the
+// packer actually needs from each element.
+type Body interface {
+	TradeBody | OrderBody | TickerBody | OrderBookBody
+	MarshalBinary() ([]byte, error)
+}
+
+// PackTyped is a type-safe PackMessage: the compiler rejects a batch mixing
+// body types, so no runtime assertion can fail. The same OrderBookBody
+// caveat applies — only the fixed 32-byte header is written; use
+// PackOrderBook to include volumes.
+func PackTyped[T Body](messageType MessageType, bodies ...T) ([]byte, error) {
+	dynamic := make([]interface{}, len(bodies))
+	for i, body := range bodies {
+		dynamic[i] = body
+	}
+	return PackMessage(messageType, dynamic...)
+}
+
+// unpackFixed decodes a message of fixed 32-byte bodies straight into a
+// concrete slice, with no boxing and no per-element assertion.
+func unpackFixed[T any, PT interface {
+	*T
+	UnmarshalBinary([]byte) error
+}](want MessageType, data []byte) (*MitchHeader, []T, error) {
+	if len(data) < HeaderSize {
+		return nil, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+	header := unpackHeader(data)
+	if header.MessageType != want {
+		return nil, nil, fmt.Errorf("mitch: message type %q is not %q", header.MessageType, want)
+	}
+	expected := HeaderSize + int(header.Count)*BodySizeFixed
+	if len(data) < expected {
+		return nil, nil, fmt.Errorf("mitch: %w: expected %d bytes for %d bodies, got %d", ErrShortBuffer, expected, header.Count, len(data))
+	}
+
+	bodies := make([]T, header.Count)
+	off := HeaderSize
+	for i := range bodies {
+		if err := PT(&bodies[i]).UnmarshalBinary(data[off : off+BodySizeFixed]); err != nil {
+			return nil, nil, err
+		}
+		off += BodySizeFixed
+	}
+	return header, bodies, nil
+}
+
+// UnpackTrades decodes a trade message into a concrete []TradeBody,
+// erroring if the header carries any other message type.
+func UnpackTrades(data []byte) (*MitchHeader, []TradeBody, error) {
+	return unpackFixed[TradeBody, *TradeBody](MsgTypeTrade, data)
+}
+
+// UnpackOrders decodes an order message into a concrete []OrderBody.
+func UnpackOrders(data []byte) (*MitchHeader, []OrderBody, error) {
+	return unpackFixed[OrderBody, *OrderBody](MsgTypeOrder, data)
+}
+
+// UnpackTickers decodes a ticker message into a concrete []TickerBody.
+func UnpackTickers(data []byte) (*MitchHeader, []TickerBody, error) {
+	return unpackFixed[TickerBody, *TickerBody](MsgTypeTicker, data)
+}
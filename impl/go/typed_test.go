@@ -0,0 +1,113 @@
+package mitch
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPackTypedMatchesPackMessage(t *testing.T) {
+	trades := []TradeBody{{TradeID: 1}, {TradeID: 2}}
+
+	typed, err := PackTyped(MsgTypeTrade, trades[0], trades[1])
+	if err != nil {
+		t.Fatalf("PackTyped: %v", err)
+	}
+	dynamic, err := PackMessage(MsgTypeTrade, trades[0], trades[1])
+	if err != nil {
+		t.Fatalf("PackMessage: %v", err)
+	}
+	// Headers carry wall-clock timestamps; compare the payloads.
+	if !bytes.Equal(typed[HeaderSize:], dynamic[HeaderSize:]) {
+		t.Error("PackTyped payload differs from PackMessage")
+	}
+
+	header, bodies, err := UnpackMessage(typed)
+	if err != nil {
+		t.Fatalf("UnpackMessage: %v", err)
+	}
+	if header.Count != 2 || bodies[1].(*TradeBody).TradeID != 2 {
+		t.Errorf("round trip: count=%d bodies=%+v", header.Count, bodies)
+	}
+}
+
+func TestPackTypedEmpty(t *testing.T) {
+	if _, err := PackTyped[TickerBody](MsgTypeTicker); err == nil {
+		t.Error("empty batch must error")
+	}
+}
+
+func TestUnpackTrades(t *testing.T) {
+	want := []TradeBody{
+		{TickerID: 1, Price: 10.5, Quantity: 100, TradeID: 1, Side: SideBuy},
+		{TickerID: 2, Price: 20.5, Quantity: 200, TradeID: 2, Side: SideSell},
+	}
+	data, err := PackTyped(MsgTypeTrade, want...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, trades, err := UnpackTrades(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Count != 2 || len(trades) != 2 {
+		t.Fatalf("decoded %d trades (Count %d), want 2", len(trades), header.Count)
+	}
+	for i := range want {
+		if trades[i] != want[i] {
+			t.Errorf("trades[%d] = %+v, want %+v", i, trades[i], want[i])
+		}
+	}
+}
+
+func TestUnpackTypedRejectsWrongType(t *testing.T) {
+	data, err := PackTyped(MsgTypeTicker, TickerBody{TickerID: 1, BidPrice: 9, AskPrice: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UnpackTrades(data); err == nil {
+		t.Error("UnpackTrades accepted a ticker message")
+	}
+	if _, tickers, err := UnpackTickers(data); err != nil || tickers[0].TickerID != 1 {
+		t.Errorf("UnpackTickers = %+v, %v", tickers, err)
+	}
+	if _, _, err := UnpackOrders(data[:HeaderSize+4]); err == nil {
+		t.Error("UnpackOrders accepted a truncated buffer")
+	}
+}
+
+func BenchmarkUnpackTrades(b *testing.B) {
+	bodies := make([]TradeBody, 100)
+	for i := range bodies {
+		bodies[i] = TradeBody{TickerID: uint64(i), Price: float64(i), TradeID: uint32(i)}
+	}
+	data, err := PackTyped(MsgTypeTrade, bodies...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := UnpackTrades(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnpackMessageBoxed(b *testing.B) {
+	bodies := make([]TradeBody, 100)
+	for i := range bodies {
+		bodies[i] = TradeBody{TickerID: uint64(i), Price: float64(i), TradeID: uint32(i)}
+	}
+	data, err := PackTyped(MsgTypeTrade, bodies...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := UnpackMessage(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
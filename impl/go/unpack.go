@@ -0,0 +1,66 @@
+// Type-dispatching unpack for generic receiver loops.
+//
+// UnpackMessage refuses order books and callers otherwise have to switch
+// on the message type before choosing a function; UnpackAny peeks at the
+// type byte and dispatches internally so one call handles every message.
+
+package mitch
+
+import "fmt"
+
+// UnpackAny deserializes any MITCH message. Fixed-size types come back as
+// *TradeBody, *OrderBody or *TickerBody, heartbeats as *HeartbeatBody,
+// book checksums as *BookChecksumBody, and order books as *OrderBookEntry
+// values carrying their volumes.
+func UnpackAny(data []byte) (*MitchHeader, []interface{}, error) {
+	if len(data) < HeaderSize {
+		return nil, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+
+	switch MessageType(data[0]) {
+	case MsgTypeOrderBook:
+		header, books, volumes, err := UnpackOrderBookBatch(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		bodies := make([]interface{}, len(books))
+		for i := range books {
+			bodies[i] = &OrderBookEntry{Body: books[i], Volumes: volumes[i]}
+		}
+		return header, bodies, nil
+	case MsgTypeBookChecksum:
+		header := unpackHeader(data)
+		bodies := make([]interface{}, 0, header.Count)
+		off := HeaderSize
+		for i := 0; i < int(header.Count); i++ {
+			body := new(BookChecksumBody)
+			if len(data) < off+BookChecksumBodySize {
+				return nil, nil, fmt.Errorf("mitch: %w: book checksum %d truncated at offset %d (len %d)", ErrShortBuffer, i, off, len(data))
+			}
+			if err := body.UnmarshalBinary(data[off:]); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+			off += BookChecksumBodySize
+		}
+		return header, bodies, nil
+	case MsgTypeHeartbeat:
+		header := unpackHeader(data)
+		bodies := make([]interface{}, 0, header.Count)
+		off := HeaderSize
+		for i := 0; i < int(header.Count); i++ {
+			body := new(HeartbeatBody)
+			if len(data) < off+HeartbeatBodySize {
+				return nil, nil, fmt.Errorf("mitch: %w: heartbeat %d truncated at offset %d (len %d)", ErrShortBuffer, i, off, len(data))
+			}
+			if err := body.UnmarshalBinary(data[off:]); err != nil {
+				return nil, nil, err
+			}
+			bodies = append(bodies, body)
+			off += HeartbeatBodySize
+		}
+		return header, bodies, nil
+	default:
+		return UnpackMessage(data)
+	}
+}
@@ -0,0 +1,49 @@
+package mitch
+
+import "testing"
+
+func TestUnpackAnyDispatch(t *testing.T) {
+	trades, err := PackMessage(MsgTypeTrade, TradeBody{TickerID: 1, TradeID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := PackOrderBookBatch(
+		[]OrderBookBody{
+			{TickerID: 2, FirstTick: 10, TickSize: 1, NumTicks: 2, Side: SideBuy},
+			{TickerID: 2, FirstTick: 11, TickSize: 1, NumTicks: 3, Side: SideSell},
+		},
+		[][]uint32{{1, 2}, {3, 4, 5}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	heartbeat, err := PackHeartbeat(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range [][]byte{trades, book, heartbeat} {
+		header, bodies, err := UnpackAny(message)
+		if err != nil {
+			t.Fatalf("UnpackAny(%q) failed: %v", message[0], err)
+		}
+		if int(header.Count) != len(bodies) {
+			t.Errorf("%q: count=%d bodies=%d", header.MessageType, header.Count, len(bodies))
+		}
+		switch header.MessageType {
+		case MsgTypeTrade:
+			if bodies[0].(*TradeBody).TradeID != 5 {
+				t.Errorf("trade = %+v", bodies[0])
+			}
+		case MsgTypeOrderBook:
+			entry := bodies[1].(*OrderBookEntry)
+			if entry.Body.Side != SideSell || len(entry.Volumes) != 3 {
+				t.Errorf("entry = %+v", entry)
+			}
+		case MsgTypeHeartbeat:
+			if bodies[0].(*HeartbeatBody).Sequence != 3 {
+				t.Errorf("heartbeat = %+v", bodies[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Semantic validation of decoded bodies.
+//
+// The unpackers only check lengths, so a corrupt feed can produce a trade
+// with Side=42 or a NaN price. Each body's Validate method checks field
+// ranges; the Decoder can run them automatically via Decoder.Validate.
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+)
+
+// ValidationError reports a body field holding an out-of-range or
+// non-finite value.
+type ValidationError struct {
+	Field string
+	Value interface{}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("mitch: invalid %s: %v", e.Field, e.Value)
+}
+
+// validFloat reports whether f is neither NaN nor infinite.
+func validFloat(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// Validate checks the trade's side and price ranges.
+func (t *TradeBody) Validate() error {
+	if t.Side > SideSell {
+		return &ValidationError{Field: "TradeBody.Side", Value: t.Side}
+	}
+	if !validFloat(t.Price) {
+		return &ValidationError{Field: "TradeBody.Price", Value: t.Price}
+	}
+	return nil
+}
+
+// Validate checks the order's extracted type and price ranges.
+func (o *OrderBody) Validate() error {
+	if orderType := o.TypeAndSide >> 1; orderType > OrderTypeModify {
+		return &ValidationError{Field: "OrderBody.TypeAndSide", Value: orderType}
+	}
+	if !validFloat(o.Price) {
+		return &ValidationError{Field: "OrderBody.Price", Value: o.Price}
+	}
+	return nil
+}
+
+// Validate checks the book's price grid: FirstTick must be finite and
+// TickSize positive and finite, since a zero or NaN TickSize makes level
+// prices nonsense and can send consumers computing level counts into
+// infinite loops. A single-level book (NumTicks <= 1) never iterates, so
+// its TickSize may be zero.
+func (b *OrderBookBody) Validate() error {
+	if !validFloat(b.FirstTick) {
+		return &ValidationError{Field: "OrderBookBody.FirstTick", Value: b.FirstTick}
+	}
+	if b.NumTicks > 1 && (!validFloat(b.TickSize) || b.TickSize <= 0) {
+		return &ValidationError{Field: "OrderBookBody.TickSize", Value: b.TickSize}
+	}
+	if !b.Side.Valid() {
+		return &ValidationError{Field: "OrderBookBody.Side", Value: b.Side}
+	}
+	return nil
+}
+
+// Validate checks the book header carried by the entry.
+func (e *OrderBookEntry) Validate() error {
+	return e.Body.Validate()
+}
+
+// Validate checks that both quotes are finite and the ask is not below the
+// bid.
+func (t *TickerBody) Validate() error {
+	if !validFloat(t.BidPrice) {
+		return &ValidationError{Field: "TickerBody.BidPrice", Value: t.BidPrice}
+	}
+	if !validFloat(t.AskPrice) {
+		return &ValidationError{Field: "TickerBody.AskPrice", Value: t.AskPrice}
+	}
+	if t.AskPrice < t.BidPrice {
+		return &ValidationError{Field: "TickerBody.AskPrice", Value: t.AskPrice}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package mitch
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOrderBookBodyValidate(t *testing.T) {
+	good := OrderBookBody{FirstTick: 100, TickSize: 0.5, NumTicks: 10}
+	if err := good.Validate(); err != nil {
+		t.Errorf("valid book: %v", err)
+	}
+	single := OrderBookBody{FirstTick: 100, TickSize: 0, NumTicks: 1}
+	if err := single.Validate(); err != nil {
+		t.Errorf("single-level book with zero TickSize: %v", err)
+	}
+
+	bad := []OrderBookBody{
+		{FirstTick: 100, TickSize: 0, NumTicks: 2},
+		{FirstTick: 100, TickSize: -0.5, NumTicks: 2},
+		{FirstTick: 100, TickSize: math.NaN(), NumTicks: 2},
+		{FirstTick: 100, TickSize: math.Inf(1), NumTicks: 2},
+		{FirstTick: math.NaN(), TickSize: 0.5, NumTicks: 2},
+		{FirstTick: math.Inf(-1), TickSize: 0.5, NumTicks: 2},
+	}
+	for i, book := range bad {
+		var verr *ValidationError
+		if err := book.Validate(); err == nil || !errors.As(err, &verr) {
+			t.Errorf("case %d (%+v): got %v, want a *ValidationError", i, book, err)
+		}
+	}
+}
+
+func TestUnpackOrderBookRejectsBadGrid(t *testing.T) {
+	// Pack a valid book, then corrupt TickSize to zero on the wire.
+	message, err := PackOrderBook(MitchHeader{},
+		OrderBookBody{TickerID: 1, FirstTick: 100, TickSize: 1, NumTicks: 2, Side: SideSell},
+		[]uint32{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 8; i++ {
+		message[HeaderSize+16+i] = 0
+	}
+	if _, _, _, err := UnpackOrderBook(message); err == nil {
+		t.Error("zero TickSize must be rejected by UnpackOrderBook")
+	}
+	if _, _, _, err := UnpackOrderBookBatch(message); err == nil {
+		t.Error("zero TickSize must be rejected by UnpackOrderBookBatch")
+	}
+}
+
+func TestBookBuilderRejectsNonFinite(t *testing.T) {
+	b := NewBookBuilder(1, SideSell)
+	b.AddLevel(math.NaN(), 1)
+	if _, _, err := b.Build(); err == nil {
+		t.Error("NaN level price must fail Build")
+	}
+}
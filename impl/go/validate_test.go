@@ -0,0 +1,59 @@
+package mitch
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBodyValidate(t *testing.T) {
+	good := TradeBody{Price: 10, Side: SideSell}
+	if err := good.Validate(); err != nil {
+		t.Errorf("valid trade rejected: %v", err)
+	}
+
+	bad := TradeBody{Price: 10, Side: 42}
+	var verr *ValidationError
+	if err := bad.Validate(); !errors.As(err, &verr) || verr.Field != "TradeBody.Side" {
+		t.Errorf("Side=42 err = %v", err)
+	}
+	nan := TradeBody{Price: math.NaN()}
+	if err := nan.Validate(); err == nil {
+		t.Error("NaN price accepted")
+	}
+
+	order := OrderBody{TypeAndSide: 0x7F << 1}
+	if err := order.Validate(); err == nil {
+		t.Error("unknown order type accepted")
+	}
+
+	crossed := TickerBody{BidPrice: 1.2, AskPrice: 1.1}
+	if err := crossed.Validate(); err == nil {
+		t.Error("ask below bid accepted")
+	}
+	inf := TickerBody{BidPrice: math.Inf(1), AskPrice: math.Inf(1)}
+	if err := inf.Validate(); err == nil {
+		t.Error("infinite bid accepted")
+	}
+}
+
+func TestDecoderValidateOption(t *testing.T) {
+	data, err := PackMessage(MsgTypeTrade, TradeBody{Price: 10, Side: 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDecoder(bytes.NewReader(data))
+	if _, _, err := d.Decode(); err != nil {
+		t.Errorf("decode without validation failed: %v", err)
+	}
+
+	d = NewDecoder(bytes.NewReader(data))
+	d.Validate(true)
+	_, _, err = d.Decode()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Errorf("decode with validation err = %v, want *ValidationError", err)
+	}
+}
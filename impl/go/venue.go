@@ -0,0 +1,28 @@
+// Venue namespacing for TickerIDs.
+//
+// When one process aggregates several venues, locally assigned instrument
+// IDs collide. The scheme here splits the uint64 TickerID into a 16-bit
+// venue in the high bits and a 48-bit instrument in the low bits, so up to
+// 65536 venues each address 2^48 instruments without overlap. Venue 0 is
+// conventionally the local/unscoped namespace, which keeps plain registry
+// IDs valid under the scheme.
+
+package mitch
+
+// MaxInstrumentID is the largest instrument ID representable in the low
+// 48 bits of a namespaced TickerID.
+const MaxInstrumentID = 1<<48 - 1
+
+// MakeTickerID packs a venue and an instrument ID into one TickerID.
+// Instrument bits above 48 are discarded so they can never corrupt the
+// venue field; callers minting IDs near the limit should check against
+// MaxInstrumentID themselves.
+func MakeTickerID(venue uint16, instrument uint64) uint64 {
+	return uint64(venue)<<48 | instrument&MaxInstrumentID
+}
+
+// SplitTickerID recovers the venue and instrument from a namespaced
+// TickerID.
+func SplitTickerID(id uint64) (venue uint16, instrument uint64) {
+	return uint16(id >> 48), id & MaxInstrumentID
+}
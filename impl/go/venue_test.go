@@ -0,0 +1,42 @@
+package mitch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTickerIDNamespacing(t *testing.T) {
+	tests := []struct {
+		venue      uint16
+		instrument uint64
+	}{
+		{0, 0},
+		{0, 42},
+		{1, 42},
+		{math.MaxUint16, MaxInstrumentID},
+	}
+	for _, tt := range tests {
+		id := MakeTickerID(tt.venue, tt.instrument)
+		venue, instrument := SplitTickerID(id)
+		if venue != tt.venue || instrument != tt.instrument {
+			t.Errorf("round trip (%d, %d) -> (%d, %d)", tt.venue, tt.instrument, venue, instrument)
+		}
+	}
+
+	// The same instrument on two venues must not collide.
+	if MakeTickerID(1, 7) == MakeTickerID(2, 7) {
+		t.Error("venues must namespace identical instrument IDs")
+	}
+}
+
+func TestMakeTickerIDMasksOverflow(t *testing.T) {
+	// Instrument bits above 48 are discarded, never leaking into the venue.
+	id := MakeTickerID(3, math.MaxUint64)
+	venue, instrument := SplitTickerID(id)
+	if venue != 3 {
+		t.Errorf("venue = %d, want 3", venue)
+	}
+	if instrument != MaxInstrumentID {
+		t.Errorf("instrument = %d, want MaxInstrumentID", instrument)
+	}
+}
@@ -0,0 +1,255 @@
+// Zero-copy decode views over raw message bytes.
+//
+// The lowest-latency receive path cannot afford to copy every body into a
+// fresh struct; a view wraps the caller's byte slice and decodes individual
+// fields on demand. Views alias the underlying buffer: they stay valid only
+// as long as the caller's buffer does, so a view must never be retained
+// past the buffer's lifetime (e.g. across Decoder.Decode calls, which reuse
+// their scratch buffer).
+
+package mitch
+
+import (
+	"fmt"
+	"math"
+)
+
+// TradeView is a zero-copy view over a 32-byte trade body. The zero view
+// (or one over a short slice) returns zero values from every accessor.
+type TradeView []byte
+
+// Valid reports whether the view covers a full 32-byte body.
+func (v TradeView) Valid() bool { return len(v) >= BodySizeFixed }
+
+// TickerID returns the ticker identifier, or 0 for a short view.
+func (v TradeView) TickerID() uint64 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint64(v[0:8])
+}
+
+// Price returns the execution price, or 0 for a short view.
+func (v TradeView) Price() float64 {
+	if !v.Valid() {
+		return 0
+	}
+	return math.Float64frombits(ByteOrder.Uint64(v[8:16]))
+}
+
+// Quantity returns the executed volume, or 0 for a short view.
+func (v TradeView) Quantity() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[16:20])
+}
+
+// TradeID returns the trade identifier, or 0 for a short view.
+func (v TradeView) TradeID() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[20:24])
+}
+
+// Side returns the trade side, or 0 for a short view.
+func (v TradeView) Side() uint8 {
+	if !v.Valid() {
+		return 0
+	}
+	return v[24]
+}
+
+// OrderView is a zero-copy view over a 32-byte order body.
+type OrderView []byte
+
+// Valid reports whether the view covers a full 32-byte body.
+func (v OrderView) Valid() bool { return len(v) >= BodySizeFixed }
+
+// TickerID returns the ticker identifier, or 0 for a short view.
+func (v OrderView) TickerID() uint64 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint64(v[0:8])
+}
+
+// OrderID returns the order identifier, or 0 for a short view.
+func (v OrderView) OrderID() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[8:12])
+}
+
+// Price returns the limit/stop price, or 0 for a short view.
+func (v OrderView) Price() float64 {
+	if !v.Valid() {
+		return 0
+	}
+	return math.Float64frombits(ByteOrder.Uint64(v[12:20]))
+}
+
+// Quantity returns the order volume, or 0 for a short view.
+func (v OrderView) Quantity() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[20:24])
+}
+
+// TypeAndSide returns the combined type/side byte, or 0 for a short view.
+func (v OrderView) TypeAndSide() uint8 {
+	if !v.Valid() {
+		return 0
+	}
+	return v[24]
+}
+
+// TickerView is a zero-copy view over a 32-byte ticker body.
+type TickerView []byte
+
+// Valid reports whether the view covers a full 32-byte body.
+func (v TickerView) Valid() bool { return len(v) >= BodySizeFixed }
+
+// TickerID returns the ticker identifier, or 0 for a short view.
+func (v TickerView) TickerID() uint64 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint64(v[0:8])
+}
+
+// BidPrice returns the best bid price, or 0 for a short view.
+func (v TickerView) BidPrice() float64 {
+	if !v.Valid() {
+		return 0
+	}
+	return math.Float64frombits(ByteOrder.Uint64(v[8:16]))
+}
+
+// AskPrice returns the best ask price, or 0 for a short view.
+func (v TickerView) AskPrice() float64 {
+	if !v.Valid() {
+		return 0
+	}
+	return math.Float64frombits(ByteOrder.Uint64(v[16:24]))
+}
+
+// BidVolume returns the volume at best bid, or 0 for a short view.
+func (v TickerView) BidVolume() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[24:28])
+}
+
+// AskVolume returns the volume at best ask, or 0 for a short view.
+func (v TickerView) AskVolume() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[28:32])
+}
+
+// HeaderView is a zero-copy view over the 8-byte message header.
+type HeaderView []byte
+
+// Valid reports whether the view covers a full 8-byte header.
+func (v HeaderView) Valid() bool { return len(v) >= HeaderSize }
+
+// MessageType returns the type byte, or 0 for a short view.
+func (v HeaderView) MessageType() MessageType {
+	if !v.Valid() {
+		return 0
+	}
+	return MessageType(v[0])
+}
+
+// Timestamp returns the nanoseconds-since-midnight timestamp, or 0 for a
+// short view.
+func (v HeaderView) Timestamp() uint64 {
+	if !v.Valid() {
+		return 0
+	}
+	var ts [6]byte
+	copy(ts[:], v[1:7])
+	return ReadTimestamp48(ts)
+}
+
+// Count returns the body count, or 0 for a short view.
+func (v HeaderView) Count() uint8 {
+	if !v.Valid() {
+		return 0
+	}
+	return v[7]
+}
+
+// ParseView slices a complete fixed-body message into zero-copy views
+// without copying any body bytes. The returned views alias data and must
+// not outlive it. Order books are refused like UnpackMessage; heartbeats
+// and the fixed 32-byte types are supported.
+func ParseView(data []byte) (messageType MessageType, views []interface{}, err error) {
+	if len(data) < HeaderSize {
+		return 0, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d)", ErrShortBuffer, len(data), HeaderSize)
+	}
+	header := HeaderView(data)
+	messageType = header.MessageType()
+	count := int(header.Count())
+
+	bodySize, ok := BodySize(messageType)
+	if !ok {
+		return 0, nil, fmt.Errorf("mitch: %w 0x%02X", ErrUnknownMessageType, messageType)
+	}
+	if bodySize == BodySizeVariable {
+		return 0, nil, fmt.Errorf("mitch: %w; see UnpackOrderBook", ErrOrderBookNeedsSpecialUnpack)
+	}
+	if len(data) < HeaderSize+count*bodySize {
+		return 0, nil, fmt.Errorf("mitch: %w: message is %d bytes (need %d for %d bodies)",
+			ErrShortBuffer, len(data), HeaderSize+count*bodySize, count)
+	}
+
+	views = make([]interface{}, 0, count)
+	off := HeaderSize
+	for i := 0; i < count; i++ {
+		raw := data[off : off+bodySize]
+		switch messageType {
+		case MsgTypeTrade:
+			views = append(views, TradeView(raw))
+		case MsgTypeOrder:
+			views = append(views, OrderView(raw))
+		case MsgTypeTicker:
+			views = append(views, TickerView(raw))
+		case MsgTypeHeartbeat:
+			views = append(views, HeartbeatView(raw))
+		}
+		off += bodySize
+	}
+	return messageType, views, nil
+}
+
+// HeartbeatView is a zero-copy view over a 12-byte heartbeat body.
+type HeartbeatView []byte
+
+// Valid reports whether the view covers a full 12-byte body.
+func (v HeartbeatView) Valid() bool { return len(v) >= HeartbeatBodySize }
+
+// Sequence returns the heartbeat sequence number, or 0 for a short view.
+func (v HeartbeatView) Sequence() uint32 {
+	if !v.Valid() {
+		return 0
+	}
+	return ByteOrder.Uint32(v[0:4])
+}
+
+// Timestamp returns the nanoseconds-since-midnight timestamp, or 0 for a
+// short view.
+func (v HeartbeatView) Timestamp() uint64 {
+	if !v.Valid() {
+		return 0
+	}
+	var ts [6]byte
+	copy(ts[:], v[4:10])
+	return ReadTimestamp48(ts)
+}
@@ -0,0 +1,101 @@
+package mitch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseViewTrades(t *testing.T) {
+	trades := []TradeBody{
+		{TickerID: 0xAABB, Price: 101.25, Quantity: 500, TradeID: 7, Side: SideSell},
+		{TickerID: 0xCCDD, Price: 99.5, Quantity: 10, TradeID: 8, Side: SideBuy},
+	}
+	message, err := PackMessage(MsgTypeTrade, trades[0], trades[1])
+	if err != nil {
+		t.Fatalf("PackMessage: %v", err)
+	}
+
+	messageType, views, err := ParseView(message)
+	if err != nil {
+		t.Fatalf("ParseView: %v", err)
+	}
+	if messageType != MsgTypeTrade {
+		t.Fatalf("messageType = %q, want %q", messageType, MsgTypeTrade)
+	}
+	if len(views) != len(trades) {
+		t.Fatalf("got %d views, want %d", len(views), len(trades))
+	}
+	for i, want := range trades {
+		v := views[i].(TradeView)
+		if v.TickerID() != want.TickerID || v.Price() != want.Price ||
+			v.Quantity() != want.Quantity || v.TradeID() != want.TradeID || v.Side() != want.Side {
+			t.Errorf("view %d = (%d %g %d %d %d), want %+v",
+				i, v.TickerID(), v.Price(), v.Quantity(), v.TradeID(), v.Side(), want)
+		}
+	}
+}
+
+func TestParseViewTicker(t *testing.T) {
+	ticker := TickerBody{TickerID: 42, BidPrice: 1.1, AskPrice: 1.2, BidVolume: 100, AskVolume: 200}
+	message, err := PackMessage(MsgTypeTicker, ticker)
+	if err != nil {
+		t.Fatalf("PackMessage: %v", err)
+	}
+	_, views, err := ParseView(message)
+	if err != nil {
+		t.Fatalf("ParseView: %v", err)
+	}
+	v := views[0].(TickerView)
+	if v.BidPrice() != 1.1 || v.AskPrice() != 1.2 || v.BidVolume() != 100 || v.AskVolume() != 200 {
+		t.Errorf("ticker view = (%g %g %d %d)", v.BidPrice(), v.AskPrice(), v.BidVolume(), v.AskVolume())
+	}
+}
+
+func TestParseViewErrors(t *testing.T) {
+	if _, _, err := ParseView([]byte{'t', 0, 0}); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("short header: got %v, want ErrShortBuffer", err)
+	}
+	if _, _, err := ParseView([]byte{'z', 0, 0, 0, 0, 0, 0, 1}); !errors.Is(err, ErrUnknownMessageType) {
+		t.Errorf("unknown type: got %v, want ErrUnknownMessageType", err)
+	}
+	if _, _, err := ParseView([]byte{'b', 0, 0, 0, 0, 0, 0, 1}); !errors.Is(err, ErrOrderBookNeedsSpecialUnpack) {
+		t.Errorf("order book: got %v, want ErrOrderBookNeedsSpecialUnpack", err)
+	}
+	if _, _, err := ParseView([]byte{'t', 0, 0, 0, 0, 0, 0, 2}); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("truncated bodies: got %v, want ErrShortBuffer", err)
+	}
+}
+
+func TestShortViewsReturnZero(t *testing.T) {
+	if TradeView(nil).Price() != 0 || TradeView([]byte{1, 2, 3}).TickerID() != 0 {
+		t.Error("short TradeView accessors must return zero")
+	}
+	if OrderView(nil).OrderID() != 0 || TickerView(nil).AskPrice() != 0 {
+		t.Error("short Order/TickerView accessors must return zero")
+	}
+	if HeaderView(nil).Count() != 0 || HeartbeatView(nil).Sequence() != 0 {
+		t.Error("short Header/HeartbeatView accessors must return zero")
+	}
+}
+
+func BenchmarkParseView(b *testing.B) {
+	bodies := make([]interface{}, 64)
+	for i := range bodies {
+		bodies[i] = TradeBody{TickerID: uint64(i), Price: 100, Quantity: 1, TradeID: uint32(i)}
+	}
+	message, err := PackMessage(MsgTypeTrade, bodies...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	var sink float64
+	for i := 0; i < b.N; i++ {
+		_, views, err := ParseView(message)
+		if err != nil {
+			b.Fatal(err)
+		}
+		sink += views[0].(TradeView).Price()
+	}
+	_ = sink
+}
@@ -0,0 +1,66 @@
+// WebSocket transport wrapper for browser dashboards.
+//
+// WebSocket frames are already length-delimited, so a packed MITCH message
+// maps one-to-one onto a binary frame with no extra framing. The wrapper
+// talks to the connection through the small WSConn interface, which
+// gorilla/websocket's *Conn satisfies directly and nhooyr-style connections
+// can satisfy with a thin adapter, keeping this module dependency-free.
+
+package mitch
+
+import "fmt"
+
+// WebSocket frame types, matching RFC 6455 opcodes and the constants used
+// by the common client libraries.
+const (
+	WSTextMessage   = 1
+	WSBinaryMessage = 2
+)
+
+// WSConn is the subset of a WebSocket connection the wrappers need.
+// *gorilla/websocket.Conn implements it as-is.
+type WSConn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+}
+
+// WSPublisher pushes packed MITCH messages over a WebSocket connection.
+type WSPublisher struct {
+	conn WSConn
+}
+
+// NewWSPublisher returns a publisher writing to conn.
+func NewWSPublisher(conn WSConn) *WSPublisher {
+	return &WSPublisher{conn: conn}
+}
+
+// Send writes one packed message as a single binary frame.
+func (p *WSPublisher) Send(data []byte) error {
+	if err := p.conn.WriteMessage(WSBinaryMessage, data); err != nil {
+		return fmt.Errorf("mitch: ws send: %w", err)
+	}
+	return nil
+}
+
+// WSSubscriber reads packed MITCH messages from a WebSocket connection.
+type WSSubscriber struct {
+	conn WSConn
+}
+
+// NewWSSubscriber returns a subscriber reading from conn.
+func NewWSSubscriber(conn WSConn) *WSSubscriber {
+	return &WSSubscriber{conn: conn}
+}
+
+// Receive reads one frame and returns its payload. MITCH is a binary
+// protocol, so a text frame is a protocol error.
+func (s *WSSubscriber) Receive() ([]byte, error) {
+	messageType, data, err := s.conn.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("mitch: ws receive: %w", err)
+	}
+	if messageType != WSBinaryMessage {
+		return nil, fmt.Errorf("mitch: ws receive: unexpected frame type %d (want binary)", messageType)
+	}
+	return data, nil
+}
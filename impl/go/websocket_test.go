@@ -0,0 +1,88 @@
+package mitch
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeWSConn queues frames for Receive and records frames from Send.
+type fakeWSConn struct {
+	inTypes  []int
+	inData   [][]byte
+	outTypes []int
+	outData  [][]byte
+	err      error
+}
+
+func (c *fakeWSConn) ReadMessage() (int, []byte, error) {
+	if c.err != nil {
+		return 0, nil, c.err
+	}
+	if len(c.inTypes) == 0 {
+		return 0, nil, errors.New("no frames queued")
+	}
+	mt, data := c.inTypes[0], c.inData[0]
+	c.inTypes, c.inData = c.inTypes[1:], c.inData[1:]
+	return mt, data, nil
+}
+
+func (c *fakeWSConn) WriteMessage(messageType int, data []byte) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.outTypes = append(c.outTypes, messageType)
+	c.outData = append(c.outData, data)
+	return nil
+}
+
+func TestWSPublisherSendsBinaryFrames(t *testing.T) {
+	conn := &fakeWSConn{}
+	message, err := PackMessage(MsgTypeTrade, TradeBody{TradeID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewWSPublisher(conn).Send(message); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(conn.outTypes) != 1 || conn.outTypes[0] != WSBinaryMessage {
+		t.Errorf("sent frame types = %v, want [%d]", conn.outTypes, WSBinaryMessage)
+	}
+	if len(conn.outData[0]) != len(message) {
+		t.Errorf("sent %d bytes, want %d", len(conn.outData[0]), len(message))
+	}
+}
+
+func TestWSSubscriberReceive(t *testing.T) {
+	message, err := PackMessage(MsgTypeTicker, TickerBody{TickerID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := &fakeWSConn{inTypes: []int{WSBinaryMessage}, inData: [][]byte{message}}
+	got, err := NewWSSubscriber(conn).Receive()
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if _, _, err := UnpackMessage(got); err != nil {
+		t.Errorf("received message does not unpack: %v", err)
+	}
+}
+
+func TestWSSubscriberRejectsTextFrames(t *testing.T) {
+	conn := &fakeWSConn{inTypes: []int{WSTextMessage}, inData: [][]byte{[]byte("hello")}}
+	_, err := NewWSSubscriber(conn).Receive()
+	if err == nil || !strings.Contains(err.Error(), "frame type") {
+		t.Errorf("text frame should be a protocol error, got %v", err)
+	}
+}
+
+func TestWSWrappersPropagateConnErrors(t *testing.T) {
+	wantErr := errors.New("connection reset")
+	conn := &fakeWSConn{err: wantErr}
+	if err := NewWSPublisher(conn).Send([]byte{1}); !errors.Is(err, wantErr) {
+		t.Errorf("Send err = %v, want %v", err, wantErr)
+	}
+	if _, err := NewWSSubscriber(conn).Receive(); !errors.Is(err, wantErr) {
+		t.Errorf("Receive err = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,27 @@
+// Wire size assertions.
+//
+// The fixed-size bodies are serialized through binary.Write, so reordering
+// fields or resizing a padding array silently changes the wire format.
+// WireSize pins each body's expected encoded size; the accompanying tests
+// compare it against binary.Size and golden byte vectors so layout drift
+// fails loudly instead of corrupting feeds.
+
+package mitch
+
+// WireSize returns the encoded size of a trade body.
+func (TradeBody) WireSize() int { return BodySizeFixed }
+
+// WireSize returns the encoded size of an order body.
+func (OrderBody) WireSize() int { return BodySizeFixed }
+
+// WireSize returns the encoded size of a ticker body.
+func (TickerBody) WireSize() int { return BodySizeFixed }
+
+// WireSize returns the encoded size of an order book header.
+func (OrderBookBody) WireSize() int { return BodySizeFixed }
+
+// WireSize returns the encoded size of a heartbeat body.
+func (HeartbeatBody) WireSize() int { return HeartbeatBodySize }
+
+// WireSize returns the encoded size of the message header.
+func (MitchHeader) WireSize() int { return HeaderSize }
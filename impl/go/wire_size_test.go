@@ -0,0 +1,96 @@
+package mitch
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestWireSizeMatchesBinarySize(t *testing.T) {
+	cases := []struct {
+		name string
+		size int
+		want int
+	}{
+		{"MitchHeader", binary.Size(MitchHeader{}), MitchHeader{}.WireSize()},
+		{"TradeBody", binary.Size(TradeBody{}), TradeBody{}.WireSize()},
+		{"OrderBody", binary.Size(OrderBody{}), OrderBody{}.WireSize()},
+		{"TickerBody", binary.Size(TickerBody{}), TickerBody{}.WireSize()},
+		{"OrderBookBody", binary.Size(OrderBookBody{}), OrderBookBody{}.WireSize()},
+		{"HeartbeatBody", binary.Size(HeartbeatBody{}), HeartbeatBody{}.WireSize()},
+	}
+	for _, tc := range cases {
+		if tc.size != tc.want {
+			t.Errorf("%s: binary.Size = %d, WireSize = %d", tc.name, tc.size, tc.want)
+		}
+	}
+}
+
+// Golden encodings: any refactor that changes the wire bytes of a body must
+// fail here before it corrupts a feed.
+func TestGoldenEncodings(t *testing.T) {
+	trade := TradeBody{
+		TickerID: 0x0102030405060708,
+		Price:    1.5,
+		Quantity: 0x11223344,
+		TradeID:  0x55667788,
+		Side:     SideSell,
+	}
+	order := OrderBody{
+		TickerID:    0x0102030405060708,
+		OrderID:     0xAABBCCDD,
+		Price:       -2.25,
+		Quantity:    0x00000010,
+		TypeAndSide: OrderTypeLimit<<1 | SideSell,
+		Expiry:      [6]byte{0, 0, 0, 0, 0, 1},
+	}
+	ticker := TickerBody{
+		TickerID:  0x0102030405060708,
+		BidPrice:  1.0,
+		AskPrice:  2.0,
+		BidVolume: 0x00000001,
+		AskVolume: 0xFFFFFFFF,
+	}
+	book := OrderBookBody{
+		TickerID:  0x0102030405060708,
+		FirstTick: 100.0,
+		TickSize:  0.5,
+		NumTicks:  0x0102,
+		Side:      SideSell,
+	}
+
+	cases := []struct {
+		name string
+		body interface{ MarshalBinary() ([]byte, error) }
+		want string
+	}{
+		{"TradeBody", trade,
+			"0102030405060708" + "3ff8000000000000" + "11223344" + "55667788" + "01" + "00000000000000"},
+		{"OrderBody", order,
+			"0102030405060708" + "aabbccdd" + "c002000000000000" + "00000010" + "03" + "000000000001" + "00"},
+		{"TickerBody", ticker,
+			"0102030405060708" + "3ff0000000000000" + "4000000000000000" + "00000001" + "ffffffff"},
+		{"OrderBookBody", book,
+			"0102030405060708" + "4059000000000000" + "3fe0000000000000" + "0102" + "01" + "0000000000"},
+	}
+	for _, tc := range cases {
+		data, err := tc.body.MarshalBinary()
+		if err != nil {
+			t.Errorf("%s: MarshalBinary: %v", tc.name, err)
+			continue
+		}
+		if got := hex.EncodeToString(data); got != tc.want {
+			t.Errorf("%s encoding drifted:\ngot  %s\nwant %s", tc.name, got, tc.want)
+		}
+
+		// The reflection-based path must produce the same bytes.
+		packed, err := PackMessage(MsgTypeTrade, tc.body)
+		if err != nil {
+			t.Errorf("%s: PackMessage: %v", tc.name, err)
+			continue
+		}
+		if got := hex.EncodeToString(packed[HeaderSize:]); got != tc.want {
+			t.Errorf("%s: binary.Write bytes diverge from MarshalBinary:\ngot  %s\nwant %s", tc.name, got, tc.want)
+		}
+	}
+}